@@ -19,148 +19,592 @@ package main
 import (
 	"../../logger"
 	pb "../../rpc"
-	"./sniffers"
 	"fmt"
 	"github.com/google/gopacket"
-	"github.com/google/gopacket/pcap"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/tcpassembly"
 	"golang.org/x/net/context"
-	"io"
 	"os"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
+	"time"
 )
 
 type Agent struct {
-	mutex         *sync.Mutex
-	packetSource  *gopacket.PacketSource
-	config        *Config
-	isHandleAlive bool
-	filter        string
-	streams       map[uint64]*Stream
-	logger        *logger.Logger
+	mutex       *sync.Mutex
+	captures    []*capture
+	config      *Config
+	signalMutex *sync.Mutex   //guards stop and done, mutex is held for the whole capture
+	stop        chan struct{} //closed to end the running capture
+	done        chan struct{} //closed once the running capture flushed its streams
+	filter      string
+	streams     map[uint64]*Stream
+	assembler   *tcpassembly.Assembler
+	logger      *logger.Logger
+	recorder    *Recorder //nil unless packets are recorded to disk
+	delivered   bool      //the results of the capture file were handed out already
 }
 
-func afpacketComputeSize(targetSizeMb int, snaplen int, pageSize int) (
-	frameSize int, blockSize int, numBlocks int, err error) {
-
-	if snaplen < pageSize {
-		frameSize = pageSize / (pageSize / snaplen)
-	} else {
-		frameSize = (snaplen/pageSize + 1) * pageSize
+func (agent *Agent) Initialize() {
+	for _, captureConfig := range agent.config.captureConfigs() {
+		for _, device := range captureConfig.Devices {
+			capture, err := openCapture(captureConfig, device)
+			if err != nil {
+				agent.logger.Error("%v", err)
+				os.Exit(1)
+			}
+			agent.logger.Info("Capturing %q on %v with %v", captureConfig.bpfFilter(), device, capture.snifferType)
+			agent.captures = append(agent.captures, capture)
+		}
+	}
+	if len(agent.captures) == 0 {
+		agent.logger.Error("No devices to capture on are configured")
+		os.Exit(1)
 	}
-	// 128 is the default from the gopacket library so just use that
-	blockSize = frameSize * 128
-	numBlocks = (targetSizeMb * 1024 * 1024) / blockSize
 
-	if numBlocks == 0 {
-		return 0, 0, 0, fmt.Errorf("Buffer size too small")
+	reassemblyConfig := &agent.config.ReassemblyConfig
+	if reassemblyConfig.MaxBufferedPagesTotal == 0 {
+		reassemblyConfig.MaxBufferedPagesTotal = defaultMaxBufferedPagesTotal
+	}
+	if reassemblyConfig.MaxBufferedPagesPerConnection == 0 {
+		reassemblyConfig.MaxBufferedPagesPerConnection = defaultMaxBufferedPagesPerConnection
+	}
+	if reassemblyConfig.FlushTimeoutInMs == 0 {
+		reassemblyConfig.FlushTimeoutInMs = defaultFlushTimeoutInMs
+	}
+	if agent.config.AnalysisConfig.LargeDocumentSizeInBytes == 0 {
+		agent.config.AnalysisConfig.LargeDocumentSizeInBytes = defaultLargeDocumentSizeInBytes
+	}
+	if agent.config.AnalysisConfig.UnansweredTimeoutInMs == 0 {
+		agent.config.AnalysisConfig.UnansweredTimeoutInMs = defaultUnansweredTimeoutInMs
 	}
-	return frameSize, blockSize, numBlocks, nil
-}
 
-func (agent *Agent) Initialize() {
-	snaplen := 1600
-	filter := fmt.Sprint("tcp and port ", agent.config.InterfaceConfig.Port)
-	if agent.config.InterfaceConfig.CaptureType == AF_PACKET {
-		var afpacketHandle *sniffers.AfpacketHandle
-		_, blockSize, numBlocks, err := afpacketComputeSize(agent.config.InterfaceConfig.AfPacketTragetSizeInMB,
-			snaplen, os.Getpagesize())
-		afpacketHandle, err = sniffers.NewAfpacketHandle(agent.config.InterfaceConfig.Device, snaplen, blockSize, numBlocks, 30)
-		if err != nil {
-			agent.logger.Error("%v", err)
-			os.Exit(1)
-		}
-		if err = afpacketHandle.SetBPFFilter(filter); err != nil {
-			agent.logger.Error("%v", err)
-			os.Exit(1)
-		} else {
-			agent.packetSource = afpacketHandle.GetPacketSource()
-		}
-	} else if agent.config.InterfaceConfig.CaptureType == PF_RING {
-		var pfringHandle *sniffers.PfringHandle
-		pfringHandle, err := sniffers.NewPfringHandle(agent.config.InterfaceConfig.Device, snaplen, true)
-		if err != nil {
-			agent.logger.Error("%v", err)
-			os.Exit(1)
-		}
-		pfringHandle.Enable()
-		if err := pfringHandle.SetBPFFilter(filter); err != nil {
-			agent.logger.Error("%v", err)
-			os.Exit(1)
-		} else {
-			agent.packetSource = pfringHandle.GetPacketSource()
-		}
-	} else {
-		var handle *pcap.Handle
-		handle, err := pcap.OpenLive(agent.config.InterfaceConfig.Device, int32(snaplen), true, pcap.BlockForever)
+	if agent.config.RecorderConfig.Directory != "" {
+		recorder, err := NewRecorder(&agent.config.RecorderConfig, recorderInterfaces(agent.captures), agent.logger)
 		if err != nil {
 			agent.logger.Error("%v", err)
 			os.Exit(1)
 		}
-		if err := handle.SetBPFFilter(filter); err != nil {
-			agent.logger.Error("%v", err)
-			os.Exit(1)
-		} else {
-			agent.packetSource = gopacket.NewPacketSource(handle, handle.LinkType())
-		}
+		agent.recorder = recorder
 	}
+}
 
-	agent.packetSource.DecodeOptions.NoCopy = true
+func (agent *Agent) newAssembler() *tcpassembly.Assembler {
+	streamPool := tcpassembly.NewStreamPool(&streamFactory{agent: agent})
+	assembler := tcpassembly.NewAssembler(streamPool)
+	assembler.MaxBufferedPagesTotal = agent.config.ReassemblyConfig.MaxBufferedPagesTotal
+	assembler.MaxBufferedPagesPerConnection = agent.config.ReassemblyConfig.MaxBufferedPagesPerConnection
+	return assembler
 }
 
 func (agent *Agent) handlePacket(packet gopacket.Packet) {
+	network := packet.NetworkLayer()
 	transport := packet.TransportLayer()
-	streamKey := transport.TransportFlow().FastHash()
-	if agent.streams[streamKey] == nil {
-		agent.streams[streamKey] = &Stream{
-			currentRequests:  make(map[uint32]*Command),
-			currentResponses: make(map[uint32]*Command),
-			mutex:            &sync.Mutex{},
+	if network == nil || transport == nil || transport.LayerType() != layers.LayerTypeTCP {
+		return
+	}
+	tcp := transport.(*layers.TCP)
+	agent.assembler.AssembleWithTimestamp(network.NetworkFlow(), tcp, packet.Metadata().Timestamp)
+
+	//a reset connection sends no further responses, a FIN only ends its direction and is handled
+	//once the data before it was reassembled
+	if tcp.RST {
+		if stream := agent.streams[streamKey(network.NetworkFlow(), tcp.TransportFlow())]; stream != nil {
+			stream.HandleClose(packet.Metadata().Timestamp.UnixNano())
 		}
 	}
-	agent.streams[streamKey].HandlePacket(transport.LayerPayload())
 }
 
-func (agent *Agent) startCapture() {
+//expireUnanswered gives up on the requests sent before olderThan
+func (agent *Agent) expireUnanswered(olderThan time.Time) {
+	for _, stream := range agent.streams {
+		stream.ExpireRequests(olderThan.UnixNano())
+	}
+}
+
+//newCapture stops the running capture, if any, and returns the channels of the next one
+func (agent *Agent) newCapture() (stop chan struct{}, done chan struct{}) {
+	agent.stopCapture()
+	agent.signalMutex.Lock()
+	defer agent.signalMutex.Unlock()
+	agent.stop = make(chan struct{})
+	agent.done = make(chan struct{})
+	return agent.stop, agent.done
+}
+
+func (agent *Agent) startCapture(stop chan struct{}, done chan struct{}) {
+	defer close(done)
 	agent.mutex.Lock() //only one capture can proceed at any point
-	agent.isHandleAlive = true
+	defer agent.mutex.Unlock()
 	agent.streams = make(map[uint64]*Stream)
+	agent.assembler = agent.newAssembler()
+	for _, capture := range agent.captures {
+		if stats, err := capture.sniffer.Stats(); err != nil {
+			agent.logger.Error("Unable to read sniffer stats of %v: %v", capture.device, err)
+		} else {
+			capture.startStats = stats
+		}
+	}
 
-	for agent.isHandleAlive {
-		packet, err := agent.packetSource.NextPacket()
-
-		if err == io.EOF {
-			agent.isHandleAlive = false
-			agent.logger.Info("Handle is no longer alive")
-			break
+	flushTimeout := time.Duration(agent.config.ReassemblyConfig.FlushTimeoutInMs) * time.Millisecond
+	unansweredTimeout := time.Duration(agent.config.AnalysisConfig.UnansweredTimeoutInMs) * time.Millisecond
+	var since time.Time
+	if !agent.isOffline() {
+		since = time.Now()
+	}
+	packets := agent.mergePackets(stop, since)
+	var lastFlush time.Time
+capture:
+	for {
+		var packet gopacket.Packet
+		select {
+		case <-stop:
+			break capture
+		case next, ok := <-packets:
+			if !ok {
+				agent.logger.Info("Handle is no longer alive")
+				break capture
+			}
+			packet = next
+		}
+		if agent.recorder != nil {
+			agent.recorder.WritePacket(packet)
 		}
 		agent.handlePacket(packet)
+
+		//give up on missing segments older than the flush timeout so buffered data is delivered with a gap
+		captureTime := packet.Metadata().Timestamp
+		if captureTime.Sub(lastFlush) > flushTimeout {
+			agent.assembler.FlushOlderThan(captureTime.Add(-flushTimeout))
+			agent.expireUnanswered(captureTime.Add(-unansweredTimeout))
+			lastFlush = captureTime
+		}
+	}
+	for range packets {
+		//wait for the forwarders to stop reading so they do not compete with the next capture
+	}
+	for _, capture := range agent.captures {
+		capture.snapshotStats()
+	}
+	agent.assembler.FlushAll()
+	if agent.recorder != nil {
+		agent.recorder.Close()
 	}
-	agent.mutex.Unlock()
+}
+
+//getCollectionNames merges the collection manifests seen on all connections by bucket
+func (agent *Agent) getCollectionNames() map[string]map[uint32]string {
+	names := make(map[string]map[uint32]string)
+	for _, stream := range agent.streams {
+		for bucket, manifest := range stream.manifests {
+			if names[bucket] == nil {
+				names[bucket] = make(map[uint32]string)
+			}
+			for collectionId, name := range manifest {
+				names[bucket][collectionId] = name
+			}
+		}
+	}
+	return names
+}
+
+//collectionName falls back to the hex collection id when no manifest for the bucket was observed
+func collectionName(names map[string]map[uint32]string, row LatencyInfo) string {
+	if name, ok := names[row.Bucket][row.CollectionId]; ok {
+		return name
+	}
+	return fmt.Sprintf("0x%x", row.CollectionId)
 }
 
 func (agent *Agent) GetResults() map[string]*pb.AgentResultsResponse_CaptureInfo {
 	responseStats := make(map[string]*pb.AgentResultsResponse_CaptureInfo)
+	collectionNames := agent.getCollectionNames()
 
 	for streamkey, stream := range agent.streams {
-		for _, row := range stream.latencyInfo {
-			responseStats[strconv.Itoa(int(row.Opaque))+strconv.FormatUint(streamkey, 10)] = &pb.AgentResultsResponse_CaptureInfo{
-				Opaque:    strconv.Itoa(int(row.Opaque)),
-				Oplatency: fmt.Sprintf("%v", row.Latency/1000),
-				Key:       row.Key,
+		//clients may reuse an opaque, the rows are told apart by how often the opaque was used before
+		rows := append([]LatencyInfo(nil), stream.latencyInfo...)
+		sort.SliceStable(rows, func(i, j int) bool { return rows[i].Timestamp < rows[j].Timestamp })
+		opaqueUses := make(map[uint32]int)
+		for _, row := range rows {
+			use := opaqueUses[row.Opaque]
+			opaqueUses[row.Opaque]++
+			captureInfo := &pb.AgentResultsResponse_CaptureInfo{
+				Opaque:           strconv.Itoa(int(row.Opaque)),
+				Oplatency:        fmt.Sprintf("%v", row.Latency/int64(time.Millisecond)),
+				LatencyInNanos:   row.Latency,
+				TimestampInNanos: row.Timestamp,
+				Key:              row.Key,
+				Opcode:           row.Opcode.String(),
+				Status:           row.Status.String(),
+				Durability:       row.Durability.String(),
+				ImpersonatedUser: row.ImpersonatedUser,
+				StreamId:         uint32(row.StreamId),
+				RequestCas:       row.RequestCas,
+				ResponseCas:      row.ResponseCas,
+				RequestDatatype:  row.RequestDatatype.String(),
+				ResponseDatatype: row.ResponseDatatype.String(),
+				RequestSize:      row.RequestSize,
+				ResponseSize:     row.ResponseSize,
+				LargeDocument:    agent.isLargeDocument(row),
+				Bucket:           row.Bucket,
+				User:             row.User,
+				Sdk:              row.Sdk,
+				Vbucket:          uint32(row.Vbucket),
+				Inferred:         row.Inferred,
+			}
+			if row.HasCollectionId {
+				captureInfo.CollectionId = row.CollectionId
+				captureInfo.Collection = collectionName(collectionNames, row)
 			}
+			if row.ServerDuration >= 0 {
+				captureInfo.Serverduration = fmt.Sprintf("%v", row.ServerDuration/int64(time.Millisecond))
+				captureInfo.ServerDurationInNanos = row.ServerDuration
+			}
+			responseStats[fmt.Sprintf("%v/%v/%v", streamkey, row.Opaque, use)] = captureInfo
 		}
 	}
 	return responseStats
 }
 
+func (agent *Agent) GetErrorStats() (map[string]uint64, map[string]*pb.AgentResultsResponse_OpcodeErrors) {
+	statusCounts := make(map[string]uint64)
+	opcodeErrors := make(map[string]*pb.AgentResultsResponse_OpcodeErrors)
+
+	for _, stream := range agent.streams {
+		for _, row := range stream.latencyInfo {
+			//the status of a quiet request closed by a NOOP is implied, a quiet get which was not
+			//answered is a miss the client expects rather than an error
+			if row.Inferred {
+				statusCounts[row.Status.String()+"_INFERRED"]++
+			} else {
+				statusCounts[row.Status.String()]++
+			}
+
+			opcode := row.Opcode.String()
+			if opcodeErrors[opcode] == nil {
+				opcodeErrors[opcode] = &pb.AgentResultsResponse_OpcodeErrors{}
+			}
+			opcodeErrors[opcode].Total++
+			if row.Status.isError() && !row.Inferred {
+				opcodeErrors[opcode].Errors++
+			}
+		}
+	}
+	return statusCounts, opcodeErrors
+}
+
+func (agent *Agent) isLargeDocument(row LatencyInfo) bool {
+	threshold := agent.config.AnalysisConfig.LargeDocumentSizeInBytes
+	return row.RequestSize > threshold || row.ResponseSize > threshold
+}
+
+//sizeBucket returns the index of the power of two bucket for size, bucket 0 holds empty values
+//and bucket i holds sizes in [2^(i-1), 2^i)
+func sizeBucket(size uint32) int {
+	bucket := 0
+	for ; size > 0; size >>= 1 {
+		bucket++
+	}
+	return bucket
+}
+
+func recordSize(histograms map[string]*pb.AgentResultsResponse_SizeHistogram, opcode string, size uint32, large bool) {
+	histogram := histograms[opcode]
+	if histogram == nil {
+		histogram = &pb.AgentResultsResponse_SizeHistogram{}
+		histograms[opcode] = histogram
+	}
+	bucket := sizeBucket(size)
+	for len(histogram.Buckets) <= bucket {
+		histogram.Buckets = append(histogram.Buckets, 0)
+	}
+	histogram.Buckets[bucket]++
+	histogram.Count++
+	histogram.TotalBytes += uint64(size)
+	if size > histogram.Max {
+		histogram.Max = size
+	}
+	if large {
+		histogram.LargeDocuments++
+	}
+}
+
+func (agent *Agent) GetSizeStats() (map[string]*pb.AgentResultsResponse_SizeHistogram, map[string]*pb.AgentResultsResponse_SizeHistogram) {
+	requestSizes := make(map[string]*pb.AgentResultsResponse_SizeHistogram)
+	responseSizes := make(map[string]*pb.AgentResultsResponse_SizeHistogram)
+	threshold := agent.config.AnalysisConfig.LargeDocumentSizeInBytes
+
+	for _, stream := range agent.streams {
+		for _, row := range stream.latencyInfo {
+			opcode := row.Opcode.String()
+			recordSize(requestSizes, opcode, row.RequestSize, row.RequestSize > threshold)
+			recordSize(responseSizes, opcode, row.ResponseSize, row.ResponseSize > threshold)
+		}
+	}
+	return requestSizes, responseSizes
+}
+
+func recordLatency(summaries map[string]*pb.AgentResultsResponse_LatencySummary, key string, latency int64) {
+	summary := summaries[key]
+	if summary == nil {
+		summary = &pb.AgentResultsResponse_LatencySummary{}
+		summaries[key] = summary
+	}
+	summary.Count++
+	summary.TotalLatencyInNanos += latency
+	if latency > summary.MaxLatencyInNanos {
+		summary.MaxLatencyInNanos = latency
+	}
+}
+
+//GetCollectionLatencies aggregates the latency of ops on collection aware connections by bucket
+//and collection
+func (agent *Agent) GetCollectionLatencies() map[string]*pb.AgentResultsResponse_LatencySummary {
+	latencies := make(map[string]*pb.AgentResultsResponse_LatencySummary)
+	collectionNames := agent.getCollectionNames()
+
+	for _, stream := range agent.streams {
+		for _, row := range stream.latencyInfo {
+			if row.HasCollectionId {
+				recordLatency(latencies, row.Bucket+"/"+collectionName(collectionNames, row), row.Latency)
+			}
+		}
+	}
+	return latencies
+}
+
+//GetSdkLatencies aggregates the latency of ops by the sdk which opened the connection, ops on
+//connections whose HELLO was not captured are not attributed
+func (agent *Agent) GetSdkLatencies() map[string]*pb.AgentResultsResponse_LatencySummary {
+	latencies := make(map[string]*pb.AgentResultsResponse_LatencySummary)
+
+	for _, stream := range agent.streams {
+		for _, row := range stream.latencyInfo {
+			if row.Sdk != "" {
+				recordLatency(latencies, row.Sdk, row.Latency)
+			}
+		}
+	}
+	return latencies
+}
+
+func (agent *Agent) GetVbucketLatencies() map[string]*pb.AgentResultsResponse_LatencySummary {
+	latencies := make(map[string]*pb.AgentResultsResponse_LatencySummary)
+
+	for _, stream := range agent.streams {
+		for _, row := range stream.latencyInfo {
+			if row.Opcode.isDocumentOp() {
+				recordLatency(latencies, strconv.Itoa(int(row.Vbucket)), row.Latency)
+			}
+		}
+	}
+	return latencies
+}
+
+//GetNotMyVbucketByClient counts NOT_MY_VBUCKET responses by client host, a client which keeps
+//getting them is working with a stale cluster map
+func (agent *Agent) GetNotMyVbucketByClient() map[string]uint64 {
+	notMyVbucket := make(map[string]uint64)
+	for _, stream := range agent.streams {
+		if stream.notMyVbucket > 0 {
+			notMyVbucket[stream.client] += stream.notMyVbucket
+		}
+	}
+	return notMyVbucket
+}
+
+//GetConfigRevisions merges the cluster map revisions seen on all connections into a timeline, a
+//revision is a topology change when its nodes differ from the previous revision of the bucket
+func (agent *Agent) GetConfigRevisions() []*pb.AgentResultsResponse_ConfigRevision {
+	var revisions []ConfigRevision
+	for _, stream := range agent.streams {
+		revisions = append(revisions, stream.configRevisions...)
+	}
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Timestamp < revisions[j].Timestamp
+	})
+
+	type version struct {
+		bucket   string
+		revEpoch int64
+		rev      int64
+	}
+	seen := make(map[version]bool)
+	lastNodes := make(map[string]string)
+	var timeline []*pb.AgentResultsResponse_ConfigRevision
+	for _, revision := range revisions {
+		key := version{revision.Bucket, revision.RevEpoch, revision.Rev}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		nodes := strings.Join(revision.Nodes, ",")
+		previous, known := lastNodes[revision.Bucket]
+		lastNodes[revision.Bucket] = nodes
+		timeline = append(timeline, &pb.AgentResultsResponse_ConfigRevision{
+			TimestampInNanos: revision.Timestamp,
+			Bucket:           revision.Bucket,
+			RevEpoch:         revision.RevEpoch,
+			Rev:              revision.Rev,
+			Source:           revision.Source,
+			Nodes:            revision.Nodes,
+			TopologyChange:   known && previous != nodes,
+		})
+	}
+	return timeline
+}
+
+func (agent *Agent) GetPushMessages() []*pb.AgentResultsResponse_PushMessage {
+	var messages []*pb.AgentResultsResponse_PushMessage
+	for streamkey, stream := range agent.streams {
+		if stream.droppedPushes > 0 {
+			agent.logger.Info("Dropped %v push messages of connection %v past the limit of %v", stream.droppedPushes,
+				streamkey, maxUnansweredOps)
+		}
+		for _, message := range stream.pushMessages {
+			pushMessage := &pb.AgentResultsResponse_PushMessage{
+				Opcode:           message.Opcode.String(),
+				TimestampInNanos: message.Timestamp,
+				Key:              message.Key,
+			}
+			if message.Latency >= 0 {
+				pushMessage.LatencyInNanos = message.Latency
+				pushMessage.Status = message.Status.String()
+				pushMessage.Answered = true
+			}
+			messages = append(messages, pushMessage)
+		}
+	}
+	return messages
+}
+
+func (agent *Agent) GetDcpConnections() map[string]*pb.AgentResultsResponse_DcpConnection {
+	connections := make(map[string]*pb.AgentResultsResponse_DcpConnection)
+	for streamkey, stream := range agent.streams {
+		if stream.dcp == nil {
+			continue
+		}
+		dcp := stream.dcp
+		connection := &pb.AgentResultsResponse_DcpConnection{
+			Name:               dcp.Name,
+			BytesSent:          dcp.BytesSent,
+			BytesAcked:         dcp.BytesAcked,
+			BufferAcks:         dcp.BufferAcks,
+			MaxUnackedBytes:    dcp.MaxUnackedBytes,
+			Rollbacks:          dcp.Rollbacks,
+			TotalAckLagInNanos: dcp.AckLag,
+			MaxAckLagInNanos:   dcp.MaxAckLag,
+			AckLagSamples:      dcp.AckLagSamples,
+		}
+		for _, dcpStream := range dcp.Streams {
+			connection.Streams = append(connection.Streams, &pb.AgentResultsResponse_DcpStream{
+				Vbucket:           uint32(dcpStream.Vbucket),
+				StreamId:          uint32(dcpStream.StreamId),
+				StartSeqno:        dcpStream.StartSeqno,
+				EndSeqno:          dcpStream.EndSeqno,
+				LastSeqno:         dcpStream.LastSeqno,
+				Mutations:         dcpStream.Mutations,
+				Deletions:         dcpStream.Deletions,
+				Expirations:       dcpStream.Expirations,
+				Snapshots:         dcpStream.Snapshots,
+				TotalSnapshotSize: dcpStream.TotalSnapshotSize,
+				MaxSnapshotSize:   dcpStream.MaxSnapshotSize,
+				SeqnoLag:          dcpStream.SeqnoLag(),
+				Ended:             dcpStream.Ended,
+			})
+		}
+		connections[strconv.FormatUint(streamkey, 10)] = connection
+	}
+	return connections
+}
+
+//GetSubdocPaths merges the per path stats of all connections, most expensive paths first
+func (agent *Agent) GetSubdocPaths() []*pb.AgentResultsResponse_SubdocPath {
+	paths := make(map[subdocPathKey]*pb.AgentResultsResponse_SubdocPath)
+	for _, stream := range agent.streams {
+		for key, stats := range stream.subdocPaths {
+			path := paths[key]
+			if path == nil {
+				path = &pb.AgentResultsResponse_SubdocPath{
+					Opcode: key.opcode.String(),
+					Path:   key.path,
+					Xattr:  key.xattr,
+				}
+				paths[key] = path
+			}
+			path.Count += stats.Count
+			path.Errors += stats.Errors
+			path.TotalLatencyInNanos += stats.TotalLatency
+			path.ValueBytes += stats.ValueBytes
+			if stats.MaxLatency > path.MaxLatencyInNanos {
+				path.MaxLatencyInNanos = stats.MaxLatency
+			}
+		}
+	}
+
+	var subdocPaths []*pb.AgentResultsResponse_SubdocPath
+	for _, path := range paths {
+		subdocPaths = append(subdocPaths, path)
+	}
+	sort.Slice(subdocPaths, func(i, j int) bool {
+		return subdocPaths[i].TotalLatencyInNanos > subdocPaths[j].TotalLatencyInNanos
+	})
+	return subdocPaths
+}
+
+func (agent *Agent) GetReadModifyWritesByClient() map[string]uint64 {
+	readModifyWrites := make(map[string]uint64)
+	for _, stream := range agent.streams {
+		if stream.readModifyWrites > 0 {
+			readModifyWrites[stream.client] += stream.readModifyWrites
+		}
+	}
+	return readModifyWrites
+}
+
+func (agent *Agent) GetUnansweredOps() []*pb.AgentResultsResponse_UnansweredOp {
+	var unansweredOps []*pb.AgentResultsResponse_UnansweredOp
+	for streamkey, stream := range agent.streams {
+		for _, op := range stream.unansweredOps {
+			unansweredOps = append(unansweredOps, &pb.AgentResultsResponse_UnansweredOp{
+				Connection:       strconv.FormatUint(streamkey, 10),
+				Opcode:           op.Opcode.String(),
+				Key:              op.Key,
+				Opaque:           op.Opaque,
+				TimestampInNanos: op.Timestamp,
+				AgeInNanos:       op.Age,
+				ConnectionClosed: op.ConnectionClosed,
+			})
+		}
+	}
+	return unansweredOps
+}
+
 func (agent *Agent) CaptureSignal(context.Context, *pb.CoordinatorCaptureRequest) (*pb.AgentCaptureResponse, error) {
-	go agent.startCapture()
+	if agent.isOffline() {
+		//the file was analyzed at startup, AgentResults hands out its results once
+		return &pb.AgentCaptureResponse{Status: "success"}, nil
+	}
+	stop, done := agent.newCapture()
+	go agent.startCapture(stop, done)
 	return &pb.AgentCaptureResponse{Status: "success"}, nil
 }
 
+//stopCapture ends the running capture and waits until its streams were flushed
 func (agent *Agent) stopCapture() {
-	agent.isHandleAlive = false
+	agent.signalMutex.Lock()
+	done := agent.done
+	if agent.stop != nil {
+		close(agent.stop)
+		agent.stop = nil
+	}
+	agent.signalMutex.Unlock()
+	if done != nil {
+		<-done
+	}
 }
 
 func (agent *Agent) shutdown() {
@@ -175,11 +619,100 @@ func (agent *Agent) GoodByeSignal(context.Context, *pb.CoordinatorGoodByeRequest
 	return &pb.AgentGoodByeResponse{Status: "success"}, nil
 }
 
+func (agent *Agent) GetReassemblyStats() (gaps uint64, skippedBytes uint64) {
+	for _, stream := range agent.streams {
+		gaps += stream.gaps
+		skippedBytes += stream.skippedBytes
+	}
+	return gaps, skippedBytes
+}
+
+func (agent *Agent) GetConnections() map[string]*pb.AgentResultsResponse_ConnectionInfo {
+	connections := make(map[string]*pb.AgentResultsResponse_ConnectionInfo)
+
+	for streamkey, stream := range agent.streams {
+		parseErrors := make(map[string]uint64)
+		for kind, count := range stream.parseErrors {
+			parseErrors[kind.String()] = count
+		}
+		connections[strconv.FormatUint(streamkey, 10)] = &pb.AgentResultsResponse_ConnectionInfo{
+			ParseErrors:        parseErrors,
+			Resyncs:            stream.resyncs,
+			ReassemblyGaps:     stream.gaps,
+			SkippedBytes:       stream.skippedBytes,
+			Bucket:             stream.bucket,
+			User:               stream.user,
+			UserAgent:          stream.userAgent,
+			ClientConnectionId: stream.clientConnectionId,
+			Features:           featureNamesOf(stream.features),
+			Client:             stream.client,
+			NotMyVbucket:       stream.notMyVbucket,
+			ReadModifyWrites:   stream.readModifyWrites,
+			Unanswered:         stream.unanswered,
+			LikelyTimeouts:     stream.likelyTimeouts,
+			ReorderedResponses: stream.reorderedResponses,
+			UnmatchedResponses: stream.unmatchedResponses,
+		}
+	}
+	return connections
+}
+
+//GetCaptureStats returns the packets every sniffer received and dropped during the last capture,
+//the results of a capture which dropped packets are incomplete
+func (agent *Agent) GetCaptureStats() []*pb.AgentResultsResponse_CaptureStats {
+	var allStats []*pb.AgentResultsResponse_CaptureStats
+	for _, capture := range agent.captures {
+		captureStats := &pb.AgentResultsResponse_CaptureStats{
+			Device:  capture.device,
+			Sniffer: capture.snifferType,
+		}
+		if capture.statsErr != nil {
+			captureStats.Error = capture.statsErr.Error()
+		} else {
+			captureStats.Received = capture.stats.Received
+			captureStats.Dropped = capture.stats.Dropped
+			captureStats.IfDropped = capture.stats.IfDropped
+		}
+		allStats = append(allStats, captureStats)
+	}
+	return allStats
+}
+
 func (agent *Agent) AgentResults(context.Context, *pb.CoordinatorResultsRequest) (*pb.AgentResultsResponse, error) {
 	agent.stopCapture()
+	agent.mutex.Lock()
+	defer agent.mutex.Unlock()
+	if agent.isOffline() {
+		//a capture file is only analyzed once, handing out its results again would count them twice
+		if agent.delivered {
+			return &pb.AgentResultsResponse{Status: "success"}, nil
+		}
+		agent.delivered = true
+	}
 	captureMap := agent.GetResults()
+	statusCounts, opcodeErrors := agent.GetErrorStats()
+	gaps, skippedBytes := agent.GetReassemblyStats()
+	requestSizes, responseSizes := agent.GetSizeStats()
 	return &pb.AgentResultsResponse{
-		Status:     "success",
-		CaptureMap: captureMap,
+		Status:                   "success",
+		CaptureMap:               captureMap,
+		StatusCounts:             statusCounts,
+		OpcodeErrors:             opcodeErrors,
+		ReassemblyGaps:           gaps,
+		SkippedBytes:             skippedBytes,
+		Connections:              agent.GetConnections(),
+		RequestSizes:             requestSizes,
+		ResponseSizes:            responseSizes,
+		CollectionLatencies:      agent.GetCollectionLatencies(),
+		SdkLatencies:             agent.GetSdkLatencies(),
+		VbucketLatencies:         agent.GetVbucketLatencies(),
+		NotMyVbucketByClient:     agent.GetNotMyVbucketByClient(),
+		ConfigRevisions:          agent.GetConfigRevisions(),
+		PushMessages:             agent.GetPushMessages(),
+		DcpConnections:           agent.GetDcpConnections(),
+		SubdocPaths:              agent.GetSubdocPaths(),
+		UnansweredOps:            agent.GetUnansweredOps(),
+		ReadModifyWritesByClient: agent.GetReadModifyWritesByClient(),
+		CaptureStats:             agent.GetCaptureStats(),
 	}, nil
 }
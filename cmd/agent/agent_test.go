@@ -0,0 +1,307 @@
+/*
+* Copyright (c) 2017 Couchbase, Inc.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package main
+
+import (
+	"../../logger"
+	"./sniffers"
+	"encoding/binary"
+	"fmt"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGetResultsKeepsReusedOpaques(t *testing.T) {
+	stream := NewStream()
+	stream.latencyInfo = []LatencyInfo{
+		{Opaque: 7, Opcode: GET, Timestamp: 200, Key: "second"},
+		{Opaque: 7, Opcode: GET, Timestamp: 100, Key: "first"},
+		{Opaque: 12, Opcode: GET, Timestamp: 300, Key: "other"},
+	}
+	otherStream := NewStream()
+	otherStream.latencyInfo = []LatencyInfo{{Opaque: 1, Opcode: GET, Timestamp: 100}}
+	agent := &Agent{
+		config:  &Config{},
+		streams: map[uint64]*Stream{3: stream, 23: otherStream},
+	}
+
+	results := agent.GetResults()
+	if len(results) != 4 {
+		t.Fatalf("expected 4 rows, got %v", len(results))
+	}
+	if results["3/7/0"].Key != "first" || results["3/7/1"].Key != "second" {
+		t.Fatalf("reused opaque rows are not keyed in request order: %v", results)
+	}
+	if results["3/12/0"] == nil || results["23/1/0"] == nil {
+		t.Fatalf("rows of different streams collide: %v", results)
+	}
+}
+
+//writePcapFile writes a connection to port 11210 which sends the request and is answered with the response
+func writePcapFile(t *testing.T, path string, request []byte, response []byte) {
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Unable to create capture file: %v", err)
+	}
+	defer file.Close()
+	writer := pcapgo.NewWriter(file)
+	if err := writer.WriteFileHeader(65536, layers.LinkTypeEthernet); err != nil {
+		t.Fatalf("Unable to write capture file: %v", err)
+	}
+	client := &endpoint{ip: net.IP{10, 0, 0, 1}, port: 50000, seq: 1000}
+	server := &endpoint{ip: net.IP{10, 0, 0, 2}, port: 11210, seq: 5000}
+	frames := [][]byte{
+		client.frame(t, server, &layers.TCP{SYN: true}, nil),
+		server.frame(t, client, &layers.TCP{SYN: true, ACK: true}, nil),
+		client.frame(t, server, &layers.TCP{ACK: true, PSH: true}, request),
+		server.frame(t, client, &layers.TCP{ACK: true, PSH: true}, response),
+	}
+	start := time.Unix(1500000000, 0)
+	for i, frame := range frames {
+		captureInfo := gopacket.CaptureInfo{
+			Timestamp:     start.Add(time.Duration(i) * time.Millisecond),
+			CaptureLength: len(frame),
+			Length:        len(frame),
+		}
+		if err := writer.WritePacket(captureInfo, frame); err != nil {
+			t.Fatalf("Unable to write capture file: %v", err)
+		}
+	}
+}
+
+func TestAnalyzeCaptureFile(t *testing.T) {
+	request := encode(MAGIC_REQUEST, GET, 1, nil, "key", nil)
+	response := encode(MAGIC_RESPONSE, GET, 1, []byte{0, 0, 0, 0}, "", []byte("value"))
+	oversized := append([]byte(nil), request...)
+	binary.BigEndian.PutUint32(oversized[8:12], 0x02000001)
+	tests := []struct {
+		name        string
+		request     []byte
+		response    []byte
+		ops         int
+		parseErrors map[ParseErrorKind]uint64
+	}{
+		{"valid", request, response, 1, nil},
+		{"truncated response", request, response[:len(response)-1], 0, nil},
+		{"oversized body length", oversized, response, 0, map[ParseErrorKind]uint64{PARSE_ERROR_BODY_LENGTH: 1}},
+	}
+	dir, err := ioutil.TempDir("", "tricorder")
+	if err != nil {
+		t.Fatalf("Unable to create directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	for i, test := range tests {
+		path := filepath.Join(dir, fmt.Sprintf("%v.pcap", i))
+		writePcapFile(t, path, test.request, test.response)
+		file, err := os.Open(path)
+		if err != nil {
+			t.Fatalf("Unable to open capture file: %v", err)
+		}
+		reader, err := pcapgo.NewReader(file)
+		if err != nil {
+			t.Fatalf("Unable to read capture file: %v", err)
+		}
+		agent := &Agent{
+			mutex:       &sync.Mutex{},
+			signalMutex: &sync.Mutex{},
+			config:      &Config{},
+			logger:      &logger.Logger{},
+		}
+		agent.config.ReassemblyConfig.FlushTimeoutInMs = 500
+		agent.config.AnalysisConfig.UnansweredTimeoutInMs = 1000
+		//the packets are replayed like the pcap file sniffer hands them out, with the timestamps of the file
+		handle := sniffers.NewMemoryHandle()
+		for {
+			data, captureInfo, err := reader.ReadPacketData()
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatalf("Unable to read capture file: %v", err)
+			}
+			handle.Inject(data, captureInfo)
+		}
+		handle.Close()
+		file.Close()
+		agent.captures = []*capture{{
+			device:       path,
+			snifferType:  sniffers.PCAP_FILE,
+			sniffer:      handle,
+			packetSource: handle.GetPacketSource(),
+		}}
+		agent.startCapture(agent.newCapture())
+
+		if ops := len(agent.GetResults()); ops != test.ops {
+			t.Errorf("%v: expected %v ops, got %v", test.name, test.ops, ops)
+		}
+		parseErrors := make(map[ParseErrorKind]uint64)
+		for _, stream := range agent.streams {
+			for kind, count := range stream.parseErrors {
+				parseErrors[kind] += count
+			}
+		}
+		if (len(test.parseErrors) > 0 || len(parseErrors) > 0) && !reflect.DeepEqual(parseErrors, test.parseErrors) {
+			t.Errorf("%v: expected parse errors %v, got %v", test.name, test.parseErrors, parseErrors)
+		}
+	}
+}
+
+//capturedSegment is the payload of one packet injected into the memory sniffer, captured at after
+//the start of the capture
+type capturedSegment struct {
+	fromClient bool
+	at         time.Duration
+	payload    []byte
+}
+
+//runCapture runs a capture of the memory sniffer over a single connection carrying the segments
+//and returns the agent once the capture has read all of them
+func runCapture(t *testing.T, segments []capturedSegment) *Agent {
+	agent := &Agent{
+		config:      &Config{Captures: []CaptureConfig{{Devices: []string{"test"}, CaptureType: sniffers.MEMORY}}},
+		mutex:       &sync.Mutex{},
+		signalMutex: &sync.Mutex{},
+		logger:      &logger.Logger{},
+	}
+	agent.Initialize()
+	handle := agent.captures[0].sniffer.(*sniffers.MemoryHandle)
+
+	client := &endpoint{ip: net.IP{10, 0, 0, 1}, port: 50000, seq: 1000}
+	server := &endpoint{ip: net.IP{10, 0, 0, 2}, port: 11210, seq: 5000}
+	//live captures drop the packets sniffed before they started
+	start := time.Now().Add(time.Second)
+	handle.Inject(client.frame(t, server, &layers.TCP{SYN: true}, nil), gopacket.CaptureInfo{Timestamp: start})
+	handle.Inject(server.frame(t, client, &layers.TCP{SYN: true, ACK: true}, nil), gopacket.CaptureInfo{Timestamp: start})
+	for _, segment := range segments {
+		from, to := server, client
+		if segment.fromClient {
+			from, to = client, server
+		}
+		captureInfo := gopacket.CaptureInfo{Timestamp: start.Add(segment.at)}
+		handle.Inject(from.frame(t, to, &layers.TCP{ACK: true, PSH: true}, segment.payload), captureInfo)
+	}
+
+	stop, done := agent.newCapture()
+	go agent.startCapture(stop, done)
+	//the capture ends by itself once the handle ran out of packets
+	handle.Close()
+	<-done
+	return agent
+}
+
+//captureSegments returns the stream of a capture of the segments
+func captureSegments(t *testing.T, segments []capturedSegment) *Stream {
+	agent := runCapture(t, segments)
+	if len(agent.streams) != 1 {
+		t.Fatalf("expected a single stream, got %v", len(agent.streams))
+	}
+	for _, stream := range agent.streams {
+		return stream
+	}
+	return nil
+}
+
+func TestMemoryCapture(t *testing.T) {
+	first := encode(MAGIC_REQUEST, GET, 1, nil, "first", nil)
+	second := encode(MAGIC_REQUEST, GET, 2, nil, "second", nil)
+	response := func(opaque uint32) []byte {
+		return encode(MAGIC_RESPONSE, GET, opaque, []byte{0, 0, 0, 0}, "", []byte("value"))
+	}
+	oversized := append([]byte(nil), first...)
+	binary.BigEndian.PutUint32(oversized[8:12], 0x02000001)
+	tests := []struct {
+		name        string
+		segments    []capturedSegment
+		keys        []string
+		latencies   []time.Duration
+		parseErrors map[ParseErrorKind]uint64
+	}{
+		{"pipelined", []capturedSegment{
+			{fromClient: true, at: time.Millisecond, payload: concat(first, second)},
+			{fromClient: false, at: 3 * time.Millisecond, payload: concat(response(1), response(2))},
+		}, []string{"first", "second"}, []time.Duration{2 * time.Millisecond, 2 * time.Millisecond}, nil},
+		//the op starts with the packet carrying the first bytes of its header
+		{"split header", []capturedSegment{
+			{fromClient: true, at: time.Millisecond, payload: first[:10]},
+			{fromClient: true, at: 2 * time.Millisecond, payload: first[10:]},
+			{fromClient: false, at: 5 * time.Millisecond, payload: response(1)},
+		}, []string{"first"}, []time.Duration{4 * time.Millisecond}, nil},
+		{"truncated response", []capturedSegment{
+			{fromClient: true, at: time.Millisecond, payload: first},
+			{fromClient: false, at: 2 * time.Millisecond, payload: response(1)[:30]},
+		}, nil, nil, nil},
+		{"oversized body length", []capturedSegment{
+			{fromClient: true, at: time.Millisecond, payload: oversized},
+			{fromClient: false, at: 2 * time.Millisecond, payload: response(1)},
+		}, nil, nil, map[ParseErrorKind]uint64{PARSE_ERROR_BODY_LENGTH: 1}},
+	}
+	for _, test := range tests {
+		stream := captureSegments(t, test.segments)
+		var keys []string
+		var latencies []time.Duration
+		for _, op := range stream.latencyInfo {
+			keys = append(keys, op.Key)
+			latencies = append(latencies, time.Duration(op.Latency))
+		}
+		if !reflect.DeepEqual(keys, test.keys) || !reflect.DeepEqual(latencies, test.latencies) {
+			t.Errorf("%v: expected ops %v taking %v, got %v taking %v", test.name, test.keys, test.latencies, keys,
+				latencies)
+		}
+		if (len(test.parseErrors) > 0 || len(stream.parseErrors) > 0) && !reflect.DeepEqual(stream.parseErrors, test.parseErrors) {
+			t.Errorf("%v: expected parse errors %v, got %v", test.name, test.parseErrors, stream.parseErrors)
+		}
+	}
+}
+
+func TestCaptureDropsPacketsSniffedBeforeItStarted(t *testing.T) {
+	stream := captureSegments(t, []capturedSegment{
+		{fromClient: true, at: -time.Hour, payload: encode(MAGIC_REQUEST, GET, 1, nil, "stale", nil)},
+		{fromClient: true, at: time.Millisecond, payload: encode(MAGIC_REQUEST, GET, 2, nil, "fresh", nil)},
+		{fromClient: false, at: 2 * time.Millisecond, payload: concat(encode(MAGIC_RESPONSE, GET, 1, nil, "", nil),
+			encode(MAGIC_RESPONSE, GET, 2, nil, "", nil))},
+	})
+	if len(stream.latencyInfo) != 1 || stream.latencyInfo[0].Key != "fresh" {
+		t.Fatalf("expected only the op sent after the capture started, got %v", stream.latencyInfo)
+	}
+}
+
+func TestCaptureStatsAreTakenWhenTheCaptureEnds(t *testing.T) {
+	agent := runCapture(t, []capturedSegment{
+		{fromClient: true, at: time.Millisecond, payload: encode(MAGIC_REQUEST, GET, 1, nil, "key", nil)},
+		{fromClient: false, at: 2 * time.Millisecond, payload: encode(MAGIC_RESPONSE, GET, 1, nil, "", nil)},
+	})
+	//the next capture starts counting from where the sniffer is by then
+	agent.captures[0].startStats.Received = 1
+
+	stats := agent.GetCaptureStats()
+	if len(stats) != 1 || stats[0].Error != "" {
+		t.Fatalf("expected the stats of one capture, got %v", stats)
+	}
+	//the handshake and both segments
+	if stats[0].Received != 4 || stats[0].Dropped != 0 {
+		t.Fatalf("expected 4 received packets, got %v", stats[0])
+	}
+}
\ No newline at end of file
@@ -0,0 +1,204 @@
+/*
+* Copyright (c) 2017 Couchbase, Inc.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package main
+
+import (
+	"./sniffers"
+	"fmt"
+	"github.com/google/gopacket"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultSnaplen   = 1600
+	packetBufferSize = 1000 //packets read by the sniffers which wait for the assembler
+)
+
+//capture is one sniffer running on one device, all of them feed the same stream table
+type capture struct {
+	device       string
+	snifferType  string
+	sniffer      sniffers.Sniffer
+	packetSource *gopacket.PacketSource
+	filter       string
+	startStats   sniffers.Stats //sniffer counters when the current capture started
+	stats        sniffers.Stats //packets received and dropped by the last capture, set when it ended
+	statsErr     error
+}
+
+//captureConfigs returns the configured captures, the interface section describes a single capture
+//for configs which do not list any
+func (config *Config) captureConfigs() []CaptureConfig {
+	if len(config.Captures) > 0 {
+		return config.Captures
+	}
+	captureConfig := CaptureConfig{
+		Devices:                []string{config.InterfaceConfig.Device},
+		CaptureType:            config.InterfaceConfig.CaptureType,
+		AfPacketTargetSizeInMB: config.InterfaceConfig.AfPacketTragetSizeInMB,
+	}
+	if config.InterfaceConfig.Port != 0 {
+		captureConfig.Ports = []int{config.InterfaceConfig.Port}
+	}
+	return []CaptureConfig{captureConfig}
+}
+
+//useCaptureFile replaces the configured captures by reading file, keeping the ports of all of them
+func (config *Config) useCaptureFile(file string) {
+	ports := make(map[int]bool)
+	for _, captureConfig := range config.captureConfigs() {
+		for _, port := range captureConfig.Ports {
+			ports[port] = true
+		}
+	}
+	captureConfig := CaptureConfig{
+		Devices:     []string{file},
+		CaptureType: sniffers.PCAP_FILE,
+	}
+	for port := range ports {
+		captureConfig.Ports = append(captureConfig.Ports, port)
+	}
+	sort.Ints(captureConfig.Ports)
+	config.Captures = []CaptureConfig{captureConfig}
+}
+
+//bpfFilter selects the tcp traffic of the capture's ports, narrowed down by the user's filter
+func (captureConfig *CaptureConfig) bpfFilter() string {
+	filter := "tcp"
+	var ports []string
+	for _, port := range captureConfig.Ports {
+		ports = append(ports, fmt.Sprint("port ", port))
+	}
+	if len(ports) > 0 {
+		filter += " and (" + strings.Join(ports, " or ") + ")"
+	}
+	if captureConfig.Filter != "" {
+		filter += " and (" + captureConfig.Filter + ")"
+	}
+	return filter
+}
+
+func openCapture(captureConfig CaptureConfig, device string) (*capture, error) {
+	snifferType := captureConfig.CaptureType
+	if snifferType == "" {
+		snifferType = sniffers.PCAP
+	}
+	snaplen := captureConfig.Snaplen
+	if snaplen == 0 {
+		snaplen = defaultSnaplen
+	}
+	sniffer, err := sniffers.New(snifferType)
+	if err != nil {
+		return nil, err
+	}
+	err = sniffer.Open(sniffers.Options{
+		Device:         device,
+		Snaplen:        snaplen,
+		Promisc:        true,
+		BufferSizeInMB: captureConfig.AfPacketTargetSizeInMB,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Unable to open %v with %v: %v", device, snifferType, err)
+	}
+	if err := sniffer.SetBPFFilter(captureConfig.bpfFilter()); err != nil {
+		sniffer.Close()
+		return nil, fmt.Errorf("Unable to set filter %q on %v: %v", captureConfig.bpfFilter(), device, err)
+	}
+	packetSource := sniffer.GetPacketSource()
+	packetSource.DecodeOptions.NoCopy = true
+	return &capture{
+		device:       device,
+		snifferType:  snifferType,
+		sniffer:      sniffer,
+		packetSource: packetSource,
+		filter:       captureConfig.bpfFilter(),
+	}, nil
+}
+
+//mergePackets reads every sniffer in its own goroutine and hands out the packets of all of them
+//on one channel, which is closed once every sniffer ran out of packets or stop was closed.
+//Packets captured before since are dropped, since is zero when reading capture files.
+func (agent *Agent) mergePackets(stop chan struct{}, since time.Time) chan gopacket.Packet {
+	packets := make(chan gopacket.Packet, packetBufferSize)
+	var wg sync.WaitGroup
+	for index, capture := range agent.captures {
+		wg.Add(1)
+		go func(index int, source chan gopacket.Packet) {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				case packet, ok := <-source:
+					if !ok {
+						return
+					}
+					//the sniffers keep buffering between captures, those packets belong to no capture
+					if packet.Metadata().Timestamp.Before(since) {
+						continue
+					}
+					//the recorder writes every capture as its own pcapng interface
+					packet.Metadata().InterfaceIndex = index
+					select {
+					case packets <- packet:
+					case <-stop:
+						return
+					}
+				}
+			}
+		}(index, capture.packetSource.Packets())
+	}
+	go func() {
+		wg.Wait()
+		close(packets)
+	}()
+	return packets
+}
+
+//counterDelta subtracts the value at the start of the capture, counters which were reset or
+//wrapped in between are taken as they are
+func counterDelta(end, start uint64) uint64 {
+	if end < start {
+		return end
+	}
+	return end - start
+}
+
+//snapshotStats keeps the counters of the capture which just ended, the sniffer keeps counting
+//until the next capture starts
+func (capture *capture) snapshotStats() {
+	stats, err := capture.sniffer.Stats()
+	capture.statsErr = err
+	capture.stats = sniffers.Stats{
+		Received:  counterDelta(stats.Received, capture.startStats.Received),
+		Dropped:   counterDelta(stats.Dropped, capture.startStats.Dropped),
+		IfDropped: counterDelta(stats.IfDropped, capture.startStats.IfDropped),
+	}
+}
+
+//isOffline is true when the agent reads a capture file instead of sniffing interfaces
+func (agent *Agent) isOffline() bool {
+	for _, capture := range agent.captures {
+		if capture.snifferType != sniffers.PCAP_FILE {
+			return false
+		}
+	}
+	return len(agent.captures) > 0
+}
\ No newline at end of file
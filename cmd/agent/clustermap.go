@@ -0,0 +1,92 @@
+/*
+* Copyright (c) 2017 Couchbase, Inc.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+//ConfigRevision is a cluster map revision observed on a connection
+type ConfigRevision struct {
+	Timestamp int64
+	Bucket    string
+	RevEpoch  int64
+	Rev       int64
+	Source    string
+	Nodes     []string
+}
+
+type clusterConfig struct {
+	Rev      int64  `json:"rev"`
+	RevEpoch int64  `json:"revEpoch"`
+	Name     string `json:"name"`
+	NodesExt []struct {
+		Hostname string         `json:"hostname"`
+		Services map[string]int `json:"services"`
+	} `json:"nodesExt"`
+	VBucketServerMap struct {
+		ServerList []string `json:"serverList"`
+	} `json:"vBucketServerMap"`
+}
+
+//nodes returns the sorted data service endpoints of the config
+func (config *clusterConfig) nodes() []string {
+	var nodes []string
+	for _, node := range config.NodesExt {
+		if port, ok := node.Services["kv"]; ok {
+			//an empty hostname stands for the node which sent the config
+			nodes = append(nodes, fmt.Sprintf("%v:%v", node.Hostname, port))
+		}
+	}
+	if len(nodes) == 0 {
+		nodes = append(nodes, config.VBucketServerMap.ServerList...)
+	}
+	sort.Strings(nodes)
+	return nodes
+}
+
+//handleClusterConfig records the cluster map carried in the value of a response when its
+//revision differs from the last one seen for the bucket on this connection
+func (stream *Stream) handleClusterConfig(bucket string, response *Command, source string) {
+	if len(response.value) == 0 {
+		//clients which negotiated deduplication get an empty NOT_MY_VBUCKET body
+		return
+	}
+	var config clusterConfig
+	if err := json.Unmarshal(response.value, &config); err != nil {
+		stream.parseErrors[PARSE_ERROR_CLUSTER_CONFIG]++
+		return
+	}
+	if config.Name != "" {
+		bucket = config.Name
+	}
+	if last, ok := stream.lastRevisions[bucket]; ok && last.RevEpoch == config.RevEpoch && last.Rev == config.Rev {
+		return
+	}
+	revision := ConfigRevision{
+		Timestamp: response.captureTimeInNanos,
+		Bucket:    bucket,
+		RevEpoch:  config.RevEpoch,
+		Rev:       config.Rev,
+		Source:    source,
+		Nodes:     config.nodes(),
+	}
+	stream.lastRevisions[bucket] = revision
+	stream.configRevisions = append(stream.configRevisions, revision)
+}
\ No newline at end of file
@@ -0,0 +1,79 @@
+/*
+* Copyright (c) 2017 Couchbase, Inc.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/binary"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"reflect"
+	"testing"
+)
+
+func TestClusterConfigFrames(t *testing.T) {
+	config := `{"rev":12,"revEpoch":1,"name":"travel","nodesExt":[{"hostname":"10.0.0.3","services":{"kv":11210}},
+	{"services":{"kv":11210,"mgmt":8091}},{"hostname":"10.0.0.4","services":{"mgmt":8091}}]}`
+	legacy := `{"rev":13,"name":"travel","vBucketServerMap":{"serverList":["10.0.0.5:11210","10.0.0.2:11210"]}}`
+	response := func(opcode Opcode, status Status, value string) []byte {
+		frame := encode(MAGIC_RESPONSE, opcode, 1, nil, "", []byte(value))
+		binary.BigEndian.PutUint16(frame[6:8], uint16(status))
+		return frame
+	}
+	oversized := response(GET_CLUSTER_CONFIG, SUCCESS, config)
+	binary.BigEndian.PutUint32(oversized[8:12], 0x02000001)
+	valid := response(GET_CLUSTER_CONFIG, SUCCESS, config)
+	tests := []struct {
+		name        string
+		opcode      Opcode
+		responses   [][]byte
+		revisions   []ConfigRevision
+		parseErrors map[ParseErrorKind]uint64
+	}{
+		{"get cluster config", GET_CLUSTER_CONFIG, [][]byte{valid}, []ConfigRevision{{Bucket: "travel", RevEpoch: 1,
+			Rev: 12, Source: "GET_CLUSTER_CONFIG", Nodes: []string{"10.0.0.3:11210", ":11210"}}}, nil},
+		{"server list", GET_CLUSTER_CONFIG, [][]byte{response(GET_CLUSTER_CONFIG, SUCCESS, legacy)},
+			[]ConfigRevision{{Bucket: "travel", Rev: 13, Source: "GET_CLUSTER_CONFIG",
+				Nodes: []string{"10.0.0.2:11210", "10.0.0.5:11210"}}}, nil},
+		{"not my vbucket", GET, [][]byte{response(GET, NOT_MY_VBUCKET, config)}, []ConfigRevision{{Bucket: "travel",
+			RevEpoch: 1, Rev: 12, Source: "NOT_MY_VBUCKET", Nodes: []string{"10.0.0.3:11210", ":11210"}}}, nil},
+		{"deduplicated not my vbucket", GET, [][]byte{response(GET, NOT_MY_VBUCKET, "")}, nil, nil},
+		{"same revision", GET_CLUSTER_CONFIG, [][]byte{valid, valid}, []ConfigRevision{{Bucket: "travel", RevEpoch: 1,
+			Rev: 12, Source: "GET_CLUSTER_CONFIG", Nodes: []string{"10.0.0.3:11210", ":11210"}}}, nil},
+		{"invalid json", GET_CLUSTER_CONFIG, [][]byte{response(GET_CLUSTER_CONFIG, SUCCESS, config[:20])}, nil,
+			map[ParseErrorKind]uint64{PARSE_ERROR_CLUSTER_CONFIG: 1}},
+		{"truncated", GET_CLUSTER_CONFIG, [][]byte{valid[:len(valid)-1]}, nil, nil},
+		{"oversized body length", GET_CLUSTER_CONFIG, [][]byte{oversized}, nil,
+			map[ParseErrorKind]uint64{PARSE_ERROR_BODY_LENGTH: 1}},
+	}
+	clientFlow := gopacket.NewFlow(layers.EndpointTCPPort, []byte{0xc3, 0x50}, []byte{0x2b, 0xca})
+	for _, test := range tests {
+		stream := NewStream()
+		for _, response := range test.responses {
+			stream.HandlePacket(clientFlow, encode(MAGIC_REQUEST, test.opcode, 1, nil, "", nil), 1000)
+			stream.HandlePacket(clientFlow.Reverse(), response, 2000)
+		}
+		for i := range stream.configRevisions {
+			stream.configRevisions[i].Timestamp = 0
+		}
+		if !reflect.DeepEqual(stream.configRevisions, test.revisions) {
+			t.Errorf("%v: expected revisions %+v, got %+v", test.name, test.revisions, stream.configRevisions)
+		}
+		if (len(test.parseErrors) > 0 || len(stream.parseErrors) > 0) && !reflect.DeepEqual(stream.parseErrors, test.parseErrors) {
+			t.Errorf("%v: expected parse errors %v, got %v", test.name, test.parseErrors, stream.parseErrors)
+		}
+	}
+}
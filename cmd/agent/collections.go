@@ -0,0 +1,91 @@
+/*
+* Copyright (c) 2017 Couchbase, Inc.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+//isDocumentOp tells whether the opcode addresses a single document, the keys of these ops start
+//with a LEB128 collection id once collections were negotiated with HELLO
+func (op Opcode) isDocumentOp() bool {
+	switch op {
+	case GET, GETQ, GETK, GETKQ, SET, SETQ, ADD, ADDQ, REPLACE, REPLACEQ, DELETE, DELETEQ,
+		INCREMENT, INCREMENTQ, DECREMENT, DECREMENTQ, APPEND, APPENDQ, PREPEND, PREPENDQ,
+		TOUCH, GAT, GATQ, GET_REPLICA, EVICT_KEY, GET_LOCKED, UNLOCK_KEY,
+		GET_META, GETQ_META, SET_WITH_META, SETQ_WITH_META, ADD_WITH_META, ADDQ_WITH_META,
+		DEL_WITH_META, DELQ_WITH_META, RETURN_META:
+		return true
+	}
+	return op >= SUBDOC_GET && op <= SUBDOC_REPLACE_BODY_XATTR
+}
+
+//decodeLeb128 returns the unsigned LEB128 value at the start of data and its encoded length,
+//the length is 0 when data does not hold a complete value
+func decodeLeb128(data []byte) (uint32, int) {
+	var value uint32
+	for i := 0; i < len(data) && i < 5; i++ {
+		value |= uint32(data[i]&0x7f) << (7 * uint(i))
+		if data[i]&0x80 == 0 {
+			return value, i + 1
+		}
+	}
+	return 0, 0
+}
+
+//decodeCollectionId strips the collection id prefix from the key of the command
+func (c *Command) decodeCollectionId() {
+	collectionId, length := decodeLeb128(c.key)
+	if length == 0 {
+		return
+	}
+	c.collectionId = collectionId
+	c.hasCollectionId = true
+	c.key = c.key[length:]
+}
+
+type collectionsManifest struct {
+	Scopes []struct {
+		Name        string `json:"name"`
+		Collections []struct {
+			Name string `json:"name"`
+			Uid  string `json:"uid"`
+		} `json:"collections"`
+	} `json:"scopes"`
+}
+
+//decodeCollectionsManifest maps the collection ids of a GET_COLLECTIONS_MANIFEST response to
+//scope.collection names
+func decodeCollectionsManifest(value []byte) (map[uint32]string, error) {
+	var manifest collectionsManifest
+	if err := json.Unmarshal(value, &manifest); err != nil {
+		return nil, err
+	}
+	names := make(map[uint32]string)
+	for _, scope := range manifest.Scopes {
+		for _, collection := range scope.Collections {
+			//uids are hex strings
+			uid, err := strconv.ParseUint(collection.Uid, 16, 32)
+			if err != nil {
+				return nil, err
+			}
+			names[uint32(uid)] = scope.Name + "." + collection.Name
+		}
+	}
+	return names, nil
+}
\ No newline at end of file
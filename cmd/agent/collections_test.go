@@ -0,0 +1,99 @@
+/*
+* Copyright (c) 2017 Couchbase, Inc.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package main
+
+import (
+	"testing"
+)
+
+func TestDecodeLeb128(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   []byte
+		value  uint32
+		length int
+	}{
+		{"default collection", []byte{0x00}, 0, 1},
+		{"one byte", []byte{0x08, 'k', 'e', 'y'}, 8, 1},
+		{"largest one byte", []byte{0x7f}, 0x7f, 1},
+		{"two bytes", []byte{0x80, 0x01, 'k'}, 0x80, 2},
+		{"three bytes", []byte{0xe5, 0x8e, 0x26}, 624485, 3},
+		{"largest id", []byte{0xff, 0xff, 0xff, 0xff, 0x0f}, 0xffffffff, 5},
+		{"empty", nil, 0, 0},
+		{"truncated", []byte{0x80}, 0, 0},
+		{"truncated multi byte", []byte{0xe5, 0x8e}, 0, 0},
+		{"longer than 5 bytes", []byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x01}, 0, 0},
+	}
+	for _, test := range tests {
+		value, length := decodeLeb128(test.data)
+		if value != test.value || length != test.length {
+			t.Errorf("%v: expected %v with length %v, got %v with length %v", test.name, test.value, test.length, value, length)
+		}
+	}
+}
+
+func TestDecodeCollectionId(t *testing.T) {
+	tests := []struct {
+		name          string
+		key           string
+		collectionId  uint32
+		hasCollection bool
+		strippedKey   string
+	}{
+		{"one byte", "\x08key", 8, true, "key"},
+		{"multi byte", "\x80\x01key", 0x80, true, "key"},
+		{"truncated", "\x80", 0, false, "\x80"},
+	}
+	for _, test := range tests {
+		command := &Command{key: []byte(test.key)}
+		command.decodeCollectionId()
+		if command.collectionId != test.collectionId || command.hasCollectionId != test.hasCollection || string(command.key) != test.strippedKey {
+			t.Errorf("%v: expected collection %v (%v) and key %q, got %v (%v) and %q", test.name, test.collectionId,
+				test.hasCollection, test.strippedKey, command.collectionId, command.hasCollectionId, command.key)
+		}
+	}
+}
+
+func TestDecodeCollectionsManifest(t *testing.T) {
+	manifest := `{"uid":"2","scopes":[{"name":"_default","uid":"0","collections":[{"name":"_default","uid":"0"},
+	{"name":"orders","uid":"a"}]},{"name":"app","uid":"8","collections":[{"name":"users","uid":"1f"}]}]}`
+	tests := []struct {
+		name  string
+		value string
+		names map[uint32]string
+		ok    bool
+	}{
+		{"valid", manifest, map[uint32]string{0: "_default._default", 0xa: "_default.orders", 0x1f: "app.users"}, true},
+		{"truncated", manifest[:40], nil, false},
+		{"uid out of range", `{"scopes":[{"name":"s","collections":[{"name":"c","uid":"100000000"}]}]}`, nil, false},
+	}
+	for _, test := range tests {
+		names, err := decodeCollectionsManifest([]byte(test.value))
+		if (err == nil) != test.ok {
+			t.Errorf("%v: unexpected error %v", test.name, err)
+			continue
+		}
+		if len(names) != len(test.names) {
+			t.Errorf("%v: expected %v, got %v", test.name, test.names, names)
+		}
+		for id, name := range test.names {
+			if names[id] != name {
+				t.Errorf("%v: expected %v for collection %v, got %v", test.name, name, id, names[id])
+			}
+		}
+	}
+}
\ No newline at end of file
@@ -19,30 +19,61 @@ package main
 import (
 	"bytes"
 	"encoding/binary"
+	"fmt"
 	"io"
-	"log"
-	"time"
+	"strings"
 )
 
 type Command struct {
 	state              ParserState
 	commandType        CommandType
-	opcode             string
+	opcode             Opcode
+	status             Status
 	magic              uint8
 	opaque             uint32
+	vbucket            uint16
 	keyLength          uint16
 	extrasLength       uint8
 	valueLength        uint32
-	cas                uint32
+	valueRead          uint32
+	cas                uint64
+	datatype           Datatype
+	extras             []byte
 	key                []byte
+	value              []byte
 	partial            []byte
 	captureTimeInNanos int64
+	sequence           uint64 //order of the requests on the connection
+	inferred           bool   //response the server never sent, implied by a later NOOP response
+	answered           bool   //matched with its response, or dropped as unanswered
+
+	framingExtrasLength uint8
+	framingExtras       []byte
+	serverDuration      int64
+	hasServerDuration   bool
+	durabilityLevel     DurabilityLevel
+	impersonatedUser    string
+	streamId            uint16
+
+	bucket string //bucket selected on the connection when the request was sent
+	user   string //user authenticated on the connection when the request was sent
+	sdk    string //sdk which identified itself with HELLO on the connection
+
+	collectionId    uint32
+	hasCollectionId bool
 }
 
+const (
+	headerLength  = 24
+	maxKeyLength  = 1024
+	maxBodyLength = 32 * 1024 * 1024 //largest document is 20MB, leave room for xattrs and extras
+)
+
 type ParserState int
 
 const (
 	parseStateHeader ParserState = iota
+	parseStateFramingExtras
 	parseStateExtras
 	parseStateKey
 	parseStateValue
@@ -54,174 +85,306 @@ type CommandType int
 const (
 	REQUEST CommandType = iota
 	RESPONSE
+	SERVER_REQUEST  //pushed by the server on a duplex connection
+	CLIENT_RESPONSE //client answer to a server request
+)
+
+const (
+	MAGIC_ALT_REQUEST     = 0x08
+	MAGIC_ALT_RESPONSE    = 0x18
+	MAGIC_REQUEST         = 0x80
+	MAGIC_RESPONSE        = 0x81
+	MAGIC_SERVER_REQUEST  = 0x82
+	MAGIC_CLIENT_RESPONSE = 0x83
 )
 
-type Opcode string
+type Datatype uint8
 
 const (
-	GET     = "0"
-	SET     = "1"
-	IGNORED = "IGNORED"
+	DATATYPE_RAW    Datatype = 0x00
+	DATATYPE_JSON   Datatype = 0x01
+	DATATYPE_SNAPPY Datatype = 0x02
+	DATATYPE_XATTR  Datatype = 0x04
 )
 
-func NewCommand() *Command {
-	return &Command{
-		state:              parseStateHeader,
-		captureTimeInNanos: time.Now().UnixNano(),
+func (datatype Datatype) String() string {
+	if datatype == DATATYPE_RAW {
+		return "RAW"
+	}
+	var names []string
+	if datatype&DATATYPE_JSON != 0 {
+		names = append(names, "JSON")
+	}
+	if datatype&DATATYPE_SNAPPY != 0 {
+		names = append(names, "SNAPPY")
+	}
+	if datatype&DATATYPE_XATTR != 0 {
+		names = append(names, "XATTR")
 	}
+	return strings.Join(names, "|")
 }
 
-func (c *Command) ReadNewPacketData(data *bytes.Buffer) error {
-	if c.state == parseStateHeader {
-		partialLen := len(c.partial)
-		if data.Len() < 24 && partialLen == 0 {
-			data.Read(c.partial)
-			return io.EOF
-		} else if data.Len() < 24 && partialLen > 0 {
-			if b, err := data.ReadByte(); err != nil {
-				if err == io.EOF {
-					return err
-				} else {
-					log.Fatalf("Failed parsing packet at partial %v", c.state, err)
-				}
-			} else {
-				c.partial = append(c.partial, b)
-			}
-			return io.EOF
-		}
+func (datatype Datatype) isValid() bool {
+	return datatype&^(DATATYPE_JSON|DATATYPE_SNAPPY|DATATYPE_XATTR) == 0
+}
 
-		var header bytes.Buffer
+type ParseErrorKind int
 
-		if len(c.partial) > 0 {
-			if n, err := header.Write(c.partial); err != nil {
-				log.Fatalf("Failed parsing packet at partial %v", c.state, err)
-			} else {
-				header.Write(data.Next(24 - n))
-			}
-		} else {
-			header.Write(data.Next(24))
-		}
+const (
+	PARSE_ERROR_MAGIC ParseErrorKind = iota
+	PARSE_ERROR_DATATYPE
+	PARSE_ERROR_KEY_LENGTH
+	PARSE_ERROR_BODY_LENGTH
+	PARSE_ERROR_UNEXPECTED
+	PARSE_ERROR_CLUSTER_CONFIG
+)
 
-		if magic, err := header.ReadByte(); err != nil {
-			log.Fatal("Failed parsing packet at magic %v", c.state, err)
-		} else {
-			c.magic = magic
-			if c.magic == 0x80 {
-				c.commandType = REQUEST
-			} else if c.magic == 0x81 {
-				c.commandType = RESPONSE
-			}
-		}
+var parseErrorKindNames = map[ParseErrorKind]string{
+	PARSE_ERROR_MAGIC:          "INVALID_MAGIC",
+	PARSE_ERROR_DATATYPE:       "INVALID_DATATYPE",
+	PARSE_ERROR_KEY_LENGTH:     "INVALID_KEY_LENGTH",
+	PARSE_ERROR_BODY_LENGTH:    "INVALID_BODY_LENGTH",
+	PARSE_ERROR_UNEXPECTED:     "UNEXPECTED",
+	PARSE_ERROR_CLUSTER_CONFIG: "INVALID_CLUSTER_CONFIG",
+}
 
-		if opcode, err := header.ReadByte(); err != nil {
-			log.Fatal("Failed parsing packet opcode %v", err)
-		} else {
-			if opcode == 0x0 {
-				c.opcode = GET
-			} else if opcode == 0x1 {
-				c.opcode = SET
-			} else {
-				c.opcode = IGNORED
-			}
+func (kind ParseErrorKind) String() string {
+	return parseErrorKindNames[kind]
+}
 
-		}
+//ParseError is returned for a header which cannot be the start of a memcached command, the
+//stream has lost track of the command boundaries and needs to resync
+type ParseError struct {
+	Kind   ParseErrorKind
+	Header []byte
+}
 
-		keyLenBytes := header.Next(2)
-		c.keyLength = binary.BigEndian.Uint16(keyLenBytes)
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%v in header % x", e.Kind, e.Header)
+}
 
-		extrasLenBytes, _ := header.ReadByte()
-		c.extrasLength = extrasLenBytes
+func isValidMagic(magic uint8) bool {
+	switch magic {
+	case MAGIC_REQUEST, MAGIC_RESPONSE, MAGIC_ALT_REQUEST, MAGIC_ALT_RESPONSE, MAGIC_SERVER_REQUEST, MAGIC_CLIENT_RESPONSE:
+		return true
+	}
+	return false
+}
+
+//validateHeader checks that header is a plausible memcached header, strict additionally
+//requires a known opcode which is used while scanning for the next command after an error
+func validateHeader(header []byte, strict bool) *ParseError {
+	magic := header[0]
+	if !isValidMagic(magic) {
+		return &ParseError{Kind: PARSE_ERROR_MAGIC, Header: header}
+	}
+	if strict {
+		known := Opcode(header[1]).isKnown()
+		if magic == MAGIC_SERVER_REQUEST || magic == MAGIC_CLIENT_RESPONSE {
+			known = ServerOpcode(header[1]).isKnown()
+		}
+		if !known {
+			return &ParseError{Kind: PARSE_ERROR_MAGIC, Header: header}
+		}
+	}
 
-		header.Next(1) //datatype
-		header.Next(2) //vbucket or status
+	var framingExtrasLength, keyLength uint32
+	if magic == MAGIC_ALT_REQUEST || magic == MAGIC_ALT_RESPONSE {
+		framingExtrasLength = uint32(header[2])
+		keyLength = uint32(header[3])
+	} else {
+		keyLength = uint32(binary.BigEndian.Uint16(header[2:4]))
+	}
+	if keyLength > maxKeyLength {
+		return &ParseError{Kind: PARSE_ERROR_KEY_LENGTH, Header: header}
+	}
+	if !Datatype(header[5]).isValid() {
+		return &ParseError{Kind: PARSE_ERROR_DATATYPE, Header: header}
+	}
+	bodyLength := binary.BigEndian.Uint32(header[8:12])
+	if bodyLength > maxBodyLength || bodyLength < framingExtrasLength+keyLength+uint32(header[4]) {
+		return &ParseError{Kind: PARSE_ERROR_BODY_LENGTH, Header: header}
+	}
+	return nil
+}
 
-		totalBodyLength := binary.BigEndian.Uint32(header.Next(4))
-		c.valueLength = totalBodyLength - uint32(c.keyLength) - uint32(c.extrasLength)
+//findHeader returns the offset of the first plausible header in data or -1. A valid magic too
+//close to the end of data to be checked is taken as a candidate as well.
+func findHeader(data []byte) int {
+	for i := 0; i < len(data); i++ {
+		if !isValidMagic(data[i]) {
+			continue
+		}
+		if len(data)-i < headerLength || validateHeader(data[i:i+headerLength], true) == nil {
+			return i
+		}
+	}
+	return -1
+}
 
-		opaqueBytes := header.Next(4)
-		c.opaque = binary.BigEndian.Uint32(opaqueBytes)
-		header.Next(2) //cas
+//NewCommand starts a command at the capture timestamp of the packet carrying its first bytes
+func NewCommand(captureTimeInNanos int64) *Command {
+	return &Command{
+		state:              parseStateHeader,
+		captureTimeInNanos: captureTimeInNanos,
+	}
+}
 
-		if data.Len() > 0 && c.extrasLength > 0 {
-			c.state = parseStateExtras
-		} else if data.Len() > 0 && c.extrasLength == 0 && c.keyLength > 0 {
-			c.state = parseStateKey
-		} else if data.Len() > 0 && c.extrasLength == 0 && c.valueLength > 0 {
-			c.state = parseStateValue
-		} else {
-			c.state = parseStateComplete
+//ReadNewPacketData consumes the bytes of this command from data and leaves whatever follows it
+//in the buffer. io.EOF is returned when data ran out before the command was complete, the parse
+//continues where it stopped on the next call.
+func (c *Command) ReadNewPacketData(data *bytes.Buffer) error {
+	if c.state == parseStateHeader {
+		c.partial = append(c.partial, data.Next(headerLength-len(c.partial))...)
+		if len(c.partial) < headerLength {
+			return io.EOF
+		}
+		if err := validateHeader(c.partial, false); err != nil {
+			return err
 		}
+		c.decodeHeader(bytes.NewBuffer(c.partial))
 		c.partial = nil
+		c.state = c.nextState(parseStateHeader)
+	}
 
+	if c.state == parseStateFramingExtras {
+		c.framingExtras = append(c.framingExtras, data.Next(int(c.framingExtrasLength)-len(c.framingExtras))...)
+		if len(c.framingExtras) < int(c.framingExtrasLength) {
+			return io.EOF
+		}
+		c.decodeFramingExtras()
+		c.state = c.nextState(parseStateFramingExtras)
 	}
 
 	if c.state == parseStateExtras {
-		extrasLen := int(c.extrasLength)
-
-		if data.Len() >= extrasLen {
-			data.Next(extrasLen)
-			if c.keyLength > 0 {
-				c.state = parseStateKey
-			} else if c.valueLength > 0 {
-				c.state = parseStateValue
-			} else {
-				c.state = parseStateComplete
-			}
-		} else {
-			available := data.Len()
-			data.Next(available)
-			c.extrasLength -= uint8(available)
+		c.extras = append(c.extras, data.Next(int(c.extrasLength)-len(c.extras))...)
+		if len(c.extras) < int(c.extrasLength) {
 			return io.EOF
 		}
+		c.state = c.nextState(parseStateExtras)
 	}
 
 	if c.state == parseStateKey {
-		keyLen := int(c.keyLength)
-
-		if data.Len() >= keyLen {
-			if c.key == nil {
-				c.key = data.Next(keyLen)
-			} else {
-				for i := 0; i < keyLen; i++ {
-					byte, _ := data.ReadByte()
-					c.key = append(c.key, byte)
-				}
-			}
-			if c.valueLength > 0 {
-				c.state = parseStateValue
-			} else {
-				c.state = parseStateComplete
-			}
-		} else {
-			available := data.Len()
-			data.Next(available)
-			c.keyLength -= uint16(available)
+		//reassembled data is reused once delivered so the key has to be copied
+		c.key = append(c.key, data.Next(int(c.keyLength)-len(c.key))...)
+		if len(c.key) < int(c.keyLength) {
 			return io.EOF
 		}
-
+		c.state = c.nextState(parseStateKey)
 	}
 
 	if c.state == parseStateValue {
-		valueLen := int(c.valueLength)
-
-		if data.Len() >= valueLen {
-			data.Next(valueLen)
-			c.state = parseStateComplete
-
-		} else {
-			available := data.Len()
-			data.Next(available)
-			c.valueLength -= uint32(available)
+		value := data.Next(int(c.valueLength - c.valueRead))
+		if c.retainsValue() {
+			c.value = append(c.value, value...)
+		}
+		c.valueRead += uint32(len(value))
+		if c.valueRead < c.valueLength {
 			return io.EOF
 		}
+		c.state = parseStateComplete
 	}
 	return nil
 }
 
+func (c *Command) decodeHeader(header *bytes.Buffer) {
+	magic, _ := header.ReadByte()
+	c.magic = magic
+	switch c.magic {
+	case MAGIC_REQUEST, MAGIC_ALT_REQUEST:
+		c.commandType = REQUEST
+	case MAGIC_RESPONSE, MAGIC_ALT_RESPONSE:
+		c.commandType = RESPONSE
+	case MAGIC_SERVER_REQUEST:
+		c.commandType = SERVER_REQUEST
+	case MAGIC_CLIENT_RESPONSE:
+		c.commandType = CLIENT_RESPONSE
+	}
+
+	opcode, _ := header.ReadByte()
+	c.opcode = Opcode(opcode)
+
+	if c.isAltMagic() {
+		//alt magic splits the key length into framing extras length and a 1 byte key length
+		framingExtrasLen, _ := header.ReadByte()
+		c.framingExtrasLength = framingExtrasLen
+		keyLen, _ := header.ReadByte()
+		c.keyLength = uint16(keyLen)
+	} else {
+		keyLenBytes := header.Next(2)
+		c.keyLength = binary.BigEndian.Uint16(keyLenBytes)
+	}
+
+	extrasLenBytes, _ := header.ReadByte()
+	c.extrasLength = extrasLenBytes
+
+	datatype, _ := header.ReadByte()
+	c.datatype = Datatype(datatype)
+
+	vbucketOrStatus := binary.BigEndian.Uint16(header.Next(2))
+	if c.commandType == RESPONSE || c.commandType == CLIENT_RESPONSE {
+		c.status = Status(vbucketOrStatus)
+	} else {
+		c.vbucket = vbucketOrStatus
+	}
+
+	totalBodyLength := binary.BigEndian.Uint32(header.Next(4))
+	c.valueLength = totalBodyLength - uint32(c.keyLength) - uint32(c.extrasLength) - uint32(c.framingExtrasLength)
+
+	opaqueBytes := header.Next(4)
+	c.opaque = binary.BigEndian.Uint32(opaqueBytes)
+	c.cas = binary.BigEndian.Uint64(header.Next(8))
+}
+
+//nextState skips the body sections which are empty for this command
+func (c *Command) nextState(state ParserState) ParserState {
+	for state++; state < parseStateComplete; state++ {
+		if state == parseStateFramingExtras && c.framingExtrasLength > 0 ||
+			state == parseStateExtras && c.extrasLength > 0 ||
+			state == parseStateKey && c.keyLength > 0 ||
+			state == parseStateValue && c.valueLength > 0 {
+			return state
+		}
+	}
+	return parseStateComplete
+}
+
+//retainsValue tells whether the value is needed to follow the session state of the connection,
+//all other values are skipped
+func (c *Command) retainsValue() bool {
+	if c.commandType == SERVER_REQUEST {
+		return c.serverOpcode() == CLUSTERMAP_CHANGE_NOTIFICATION
+	}
+	if c.commandType == CLIENT_RESPONSE {
+		return false
+	}
+	if c.opcode.isSubdoc() {
+		//responses are needed for the per path status of multi path commands only
+		return !c.isResponse() || c.opcode == SUBDOC_MULTI_LOOKUP || c.opcode == SUBDOC_MULTI_MUTATION
+	}
+	switch c.opcode {
+	case SASL_AUTH, DCP_SNAPSHOT_MARKER:
+		return !c.isResponse()
+	case HELLO, COLLECTIONS_GET_MANIFEST, GET_CLUSTER_CONFIG:
+		return c.isResponse()
+	}
+	//NOT_MY_VBUCKET responses carry the cluster map of the server
+	return c.isResponse() && c.status == NOT_MY_VBUCKET
+}
+
 func (c *Command) isComplete() bool {
 	return c.state == parseStateComplete
 }
 
+func (c *Command) isAltMagic() bool {
+	return c.magic == MAGIC_ALT_REQUEST || c.magic == MAGIC_ALT_RESPONSE
+}
+
 func (c *Command) isResponse() bool {
 	return c.commandType == RESPONSE
+}
+
+//serverOpcode interprets the opcode of a server request or client response
+func (c *Command) serverOpcode() ServerOpcode {
+	return ServerOpcode(c.opcode)
 }
\ No newline at end of file
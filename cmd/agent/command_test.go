@@ -0,0 +1,111 @@
+/*
+* Copyright (c) 2017 Couchbase, Inc.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+	"testing"
+)
+
+func TestCasAndDatatype(t *testing.T) {
+	withHeader := func(frame []byte, offset int, field []byte) []byte {
+		frame = append([]byte(nil), frame...)
+		copy(frame[offset:], field)
+		return frame
+	}
+	get := encode(MAGIC_RESPONSE, GET, 1, []byte{0, 0, 0, 0}, "", []byte(`{"a":1}`))
+	fullCas := withHeader(get, 16, []byte{0x16, 0x05, 0xe4, 0x2a, 0x7c, 0x01, 0x00, 0x02})
+	json := withHeader(get, 5, []byte{byte(DATATYPE_JSON)})
+	compressedXattr := withHeader(get, 5, []byte{byte(DATATYPE_SNAPPY | DATATYPE_XATTR)})
+	tests := []struct {
+		name     string
+		frame    []byte
+		err      error
+		cas      uint64
+		datatype Datatype
+	}{
+		{"raw", get, nil, 0, DATATYPE_RAW},
+		{"64-bit cas", fullCas, nil, 0x1605e42a7c010002, DATATYPE_RAW},
+		{"json", json, nil, 0, DATATYPE_JSON},
+		{"snappy and xattr", compressedXattr, nil, 0, DATATYPE_SNAPPY | DATATYPE_XATTR},
+		{"truncated cas", fullCas[:20], io.EOF, 0, DATATYPE_RAW},
+		{"truncated value", fullCas[:len(fullCas)-1], io.EOF, 0x1605e42a7c010002, DATATYPE_RAW},
+		{"unknown datatype", withHeader(get, 5, []byte{0x08}), &ParseError{Kind: PARSE_ERROR_DATATYPE}, 0, DATATYPE_RAW},
+		{"oversized body length", withHeader(get, 8, []byte{0x02, 0x00, 0x00, 0x01}), &ParseError{Kind: PARSE_ERROR_BODY_LENGTH}, 0, DATATYPE_RAW},
+		{"oversized key length", withHeader(get, 2, []byte{0x04, 0x01}), &ParseError{Kind: PARSE_ERROR_KEY_LENGTH}, 0, DATATYPE_RAW},
+	}
+	for _, test := range tests {
+		command, err := decode(test.frame)
+		if !sameError(err, test.err) {
+			t.Errorf("%v: expected error %v, got %v", test.name, test.err, err)
+			continue
+		}
+		if command.cas != test.cas || command.datatype != test.datatype {
+			t.Errorf("%v: expected cas %x and datatype %v, got %x and %v", test.name, test.cas, test.datatype, command.cas, command.datatype)
+		}
+	}
+}
+
+func TestDatatypeString(t *testing.T) {
+	tests := []struct {
+		datatype Datatype
+		name     string
+	}{
+		{DATATYPE_RAW, "RAW"},
+		{DATATYPE_JSON, "JSON"},
+		{DATATYPE_JSON | DATATYPE_SNAPPY, "JSON|SNAPPY"},
+		{DATATYPE_JSON | DATATYPE_SNAPPY | DATATYPE_XATTR, "JSON|SNAPPY|XATTR"},
+	}
+	for _, test := range tests {
+		if name := test.datatype.String(); name != test.name {
+			t.Errorf("expected %v for %#x, got %v", test.name, uint8(test.datatype), name)
+		}
+	}
+}
+
+func TestVbucketFrames(t *testing.T) {
+	request := encode(MAGIC_REQUEST, GET, 1, nil, "key", nil)
+	request[6], request[7] = 0x03, 0xff
+	response := encode(MAGIC_RESPONSE, GET, 1, nil, "", []byte("Not my vbucket"))
+	response[6], response[7] = 0x00, byte(NOT_MY_VBUCKET)
+	oversized := append([]byte(nil), request...)
+	oversized[2], oversized[3] = 0x04, 0x01
+	tests := []struct {
+		name    string
+		frame   []byte
+		err     error
+		vbucket uint16
+		status  Status
+	}{
+		{"request", request, nil, 0x03ff, SUCCESS},
+		{"not my vbucket", response, nil, 0, NOT_MY_VBUCKET},
+		{"truncated header", request[:7], io.EOF, 0, SUCCESS},
+		{"truncated key", request[:headerLength+1], io.EOF, 0x03ff, SUCCESS},
+		{"oversized key length", oversized, &ParseError{Kind: PARSE_ERROR_KEY_LENGTH}, 0, SUCCESS},
+	}
+	for _, test := range tests {
+		command, err := decode(test.frame)
+		if !sameError(err, test.err) {
+			t.Errorf("%v: expected error %v, got %v", test.name, test.err, err)
+			continue
+		}
+		if command.vbucket != test.vbucket || command.status != test.status {
+			t.Errorf("%v: expected vbucket %v and status %v, got %v and %v", test.name, test.vbucket, test.status,
+				command.vbucket, command.status)
+		}
+	}
+}
\ No newline at end of file
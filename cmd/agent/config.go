@@ -17,9 +17,13 @@
 package main
 
 type Config struct {
-	Port            int             `yaml:"port"`
-	InterfaceConfig InterfaceConfig `yaml:"interface"`
-	logging         LoggingConfig   `yaml:"log"`
+	Port             int              `yaml:"port"`
+	InterfaceConfig  InterfaceConfig  `yaml:"interface"`
+	Captures         []CaptureConfig  `yaml:"captures"`
+	ReassemblyConfig ReassemblyConfig `yaml:"reassembly"`
+	AnalysisConfig   AnalysisConfig   `yaml:"analysis"`
+	RecorderConfig   RecorderConfig   `yaml:"recorder"`
+	logging          LoggingConfig    `yaml:"log"`
 }
 
 type InterfaceConfig struct {
@@ -29,9 +33,39 @@ type InterfaceConfig struct {
 	Port                   int    `yaml:"port"`
 }
 
+//CaptureConfig runs a sniffer on each of the devices, capturing the tcp traffic of the ports
+type CaptureConfig struct {
+	Devices                []string `yaml:"devices"`
+	Ports                  []int    `yaml:"ports"`
+	Filter                 string   `yaml:"filter"` //BPF expression which further restricts the captured packets
+	Snaplen                int      `yaml:"snaplen"`
+	CaptureType            string   `yaml:"type"`
+	AfPacketTargetSizeInMB int      `yaml:"targetsize"`
+}
+
+type ReassemblyConfig struct {
+	MaxBufferedPagesTotal         int `yaml:"maxbufferedpagestotal"`
+	MaxBufferedPagesPerConnection int `yaml:"maxbufferedpagesperconnection"`
+	FlushTimeoutInMs              int `yaml:"flushtimeout"`
+}
+
+type AnalysisConfig struct {
+	LargeDocumentSizeInBytes uint32 `yaml:"largedocumentsize"`
+	UnansweredTimeoutInMs    int    `yaml:"unansweredtimeout"`
+}
+
+type RecorderConfig struct {
+	Directory           string `yaml:"directory"` //the recorder is disabled unless a directory is set
+	FileSizeInMB        int    `yaml:"filesize"`
+	Files               int    `yaml:"files"`
+	PreservedFiles      int    `yaml:"preservedfiles"`
+	SlowOpThresholdInMs int    `yaml:"slowop"`
+	WindowInMs          int    `yaml:"window"`
+}
+
 const (
-	AF_PACKET = "afpacket"
-	PF_RING   = "pfring"
+	defaultLargeDocumentSizeInBytes = 1024 * 1024
+	defaultUnansweredTimeoutInMs    = 2500 //default kv timeout of the sdks
 )
 
 type LoggingConfig struct {
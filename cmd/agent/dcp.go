@@ -0,0 +1,233 @@
+/*
+* Copyright (c) 2017 Couchbase, Inc.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package main
+
+import "encoding/binary"
+
+const (
+	maxDcpAckMarks      = 10000
+	dcpStreamReqExtras  = 48
+	dcpSnapshotV1Extras = 20
+)
+
+//DcpConnection follows the DCP traffic of one connection, the producer sends stream messages
+//and the consumer acknowledges the bytes it processed when flow control is enabled
+type DcpConnection struct {
+	Name            string
+	BytesSent       uint64
+	BytesAcked      uint64
+	BufferAcks      uint64
+	MaxUnackedBytes uint64
+	Rollbacks       uint64
+	AckLag          int64 //total time between a snapshot marker and the buffer ack covering it
+	MaxAckLag       int64
+	AckLagSamples   uint64
+	Streams         map[dcpStreamKey]*DcpStream
+	ackMarks        []dcpAckMark
+}
+
+type dcpStreamKey struct {
+	vbucket  uint16
+	streamId uint16
+}
+
+//dcpAckMark remembers when the producer had sent a given number of bytes
+type dcpAckMark struct {
+	bytesSent uint64
+	timestamp int64
+}
+
+type DcpStream struct {
+	Vbucket           uint16
+	StreamId          uint16
+	StartSeqno        uint64
+	EndSeqno          uint64
+	LastSeqno         uint64
+	Mutations         uint64
+	Deletions         uint64
+	Expirations       uint64
+	Snapshots         uint64
+	SnapshotEnd       uint64
+	TotalSnapshotSize uint64
+	MaxSnapshotSize   uint64
+	Ended             bool
+}
+
+func newDcpConnection() *DcpConnection {
+	return &DcpConnection{
+		Streams: make(map[dcpStreamKey]*DcpStream),
+	}
+}
+
+func (connection *DcpConnection) stream(vbucket, streamId uint16) *DcpStream {
+	key := dcpStreamKey{vbucket, streamId}
+	dcpStream := connection.Streams[key]
+	if dcpStream == nil {
+		//streams requested before the capture started show up with their first message
+		dcpStream = &DcpStream{Vbucket: vbucket, StreamId: streamId}
+		connection.Streams[key] = dcpStream
+	}
+	return dcpStream
+}
+
+//SeqnoLag estimates how far the consumer is behind the producer as the number of seqnos of the
+//current snapshot which have not been received yet
+func (dcpStream *DcpStream) SeqnoLag() uint64 {
+	if dcpStream.SnapshotEnd > dcpStream.LastSeqno {
+		return dcpStream.SnapshotEnd - dcpStream.LastSeqno
+	}
+	return 0
+}
+
+func (dcpStream *DcpStream) received(seqno uint64) {
+	if seqno > dcpStream.LastSeqno {
+		dcpStream.LastSeqno = seqno
+	}
+}
+
+//totalLength is the number of bytes the command took on the wire, which is what DCP flow control counts
+func (c *Command) totalLength() uint64 {
+	return headerLength + uint64(c.framingExtrasLength) + uint64(c.extrasLength) + uint64(c.keyLength) + uint64(c.valueLength)
+}
+
+func (stream *Stream) dcpConnection() *DcpConnection {
+	if stream.dcp == nil {
+		stream.dcp = newDcpConnection()
+	}
+	return stream.dcp
+}
+
+//handleDcpRequest decodes the DCP messages which are not answered, they all reuse the opaque of
+//their stream request and must not take part in request/response matching
+func (stream *Stream) handleDcpRequest(request *Command) bool {
+	switch request.opcode {
+	case DCP_SNAPSHOT_MARKER, DCP_MUTATION, DCP_DELETION, DCP_EXPIRATION, DCP_STREAM_END, DCP_SYSTEM_EVENT,
+		DCP_PREPARE, DCP_COMMIT, DCP_ABORT, DCP_SEQNO_ADVANCED, DCP_OSO_SNAPSHOT:
+		stream.handleDcpStreamMessage(request)
+		return true
+	case DCP_BUFFER_ACKNOWLEDGEMENT:
+		if len(request.extras) >= 4 {
+			stream.handleDcpBufferAck(uint64(binary.BigEndian.Uint32(request.extras)), request.captureTimeInNanos)
+		}
+		return true
+	case DCP_OPEN:
+		stream.dcpConnection().Name = string(request.key)
+	}
+	return false
+}
+
+func (stream *Stream) handleDcpStreamMessage(message *Command) {
+	connection := stream.dcpConnection()
+	connection.BytesSent += message.totalLength()
+	if unacked := connection.BytesSent - connection.BytesAcked; unacked > connection.MaxUnackedBytes {
+		connection.MaxUnackedBytes = unacked
+	}
+
+	dcpStream := connection.stream(message.vbucket, message.streamId)
+	extras := message.extras
+	switch message.opcode {
+	case DCP_SNAPSHOT_MARKER:
+		//v1 markers carry the range in the extras, v2 markers have a 1 byte version in the extras
+		//and the range in the value
+		snapshot := extras
+		if len(extras) != dcpSnapshotV1Extras {
+			snapshot = message.value
+		}
+		if len(snapshot) < 16 {
+			return
+		}
+		start := binary.BigEndian.Uint64(snapshot[0:8])
+		end := binary.BigEndian.Uint64(snapshot[8:16])
+		dcpStream.Snapshots++
+		dcpStream.SnapshotEnd = end
+		if end >= start {
+			size := end - start + 1
+			dcpStream.TotalSnapshotSize += size
+			if size > dcpStream.MaxSnapshotSize {
+				dcpStream.MaxSnapshotSize = size
+			}
+		}
+		if len(connection.ackMarks) < maxDcpAckMarks {
+			connection.ackMarks = append(connection.ackMarks, dcpAckMark{connection.BytesSent, message.captureTimeInNanos})
+		}
+	case DCP_MUTATION, DCP_PREPARE:
+		dcpStream.Mutations++
+		if len(extras) >= 8 {
+			dcpStream.received(binary.BigEndian.Uint64(extras))
+		}
+	case DCP_DELETION:
+		dcpStream.Deletions++
+		if len(extras) >= 8 {
+			dcpStream.received(binary.BigEndian.Uint64(extras))
+		}
+	case DCP_EXPIRATION:
+		dcpStream.Expirations++
+		if len(extras) >= 8 {
+			dcpStream.received(binary.BigEndian.Uint64(extras))
+		}
+	case DCP_SYSTEM_EVENT, DCP_SEQNO_ADVANCED:
+		if len(extras) >= 8 {
+			dcpStream.received(binary.BigEndian.Uint64(extras))
+		}
+	case DCP_COMMIT, DCP_ABORT:
+		//prepared seqno followed by the seqno of the commit or abort
+		if len(extras) >= 16 {
+			dcpStream.received(binary.BigEndian.Uint64(extras[8:16]))
+		}
+	case DCP_STREAM_END:
+		dcpStream.Ended = true
+	}
+}
+
+//handleDcpBufferAck accounts for bytes the consumer processed, the time from a snapshot marker
+//to the ack covering it is taken as the lag of the consumer
+func (stream *Stream) handleDcpBufferAck(bytes uint64, timestamp int64) {
+	connection := stream.dcpConnection()
+	connection.BufferAcks++
+	connection.BytesAcked += bytes
+
+	acked := 0
+	for ; acked < len(connection.ackMarks) && connection.ackMarks[acked].bytesSent <= connection.BytesAcked; acked++ {
+		lag := timestamp - connection.ackMarks[acked].timestamp
+		connection.AckLag += lag
+		connection.AckLagSamples++
+		if lag > connection.MaxAckLag {
+			connection.MaxAckLag = lag
+		}
+	}
+	connection.ackMarks = connection.ackMarks[acked:]
+}
+
+//handleDcpResponse picks up the stream range of an accepted stream request
+func (stream *Stream) handleDcpResponse(request, response *Command) {
+	if request.opcode != DCP_STREAM_REQ {
+		return
+	}
+	connection := stream.dcpConnection()
+	if response.status == ROLLBACK {
+		connection.Rollbacks++
+		return
+	}
+	if response.status != SUCCESS || len(request.extras) < dcpStreamReqExtras {
+		return
+	}
+	dcpStream := connection.stream(request.vbucket, request.streamId)
+	dcpStream.StartSeqno = binary.BigEndian.Uint64(request.extras[8:16])
+	dcpStream.EndSeqno = binary.BigEndian.Uint64(request.extras[16:24])
+	dcpStream.LastSeqno = dcpStream.StartSeqno
+	dcpStream.Ended = false
+}
\ No newline at end of file
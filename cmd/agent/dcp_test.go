@@ -0,0 +1,107 @@
+/*
+* Copyright (c) 2017 Couchbase, Inc.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/binary"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"reflect"
+	"testing"
+)
+
+//dcpFrame builds a DCP message of vbucket 5, responses carry status instead of the vbucket
+func dcpFrame(magic byte, opcode Opcode, status Status, extras ...uint64) []byte {
+	extrasBytes := make([]byte, 8*len(extras))
+	for i, extra := range extras {
+		binary.BigEndian.PutUint64(extrasBytes[8*i:], extra)
+	}
+	frame := encode(magic, opcode, 1, extrasBytes, "", nil)
+	if magic == MAGIC_RESPONSE {
+		binary.BigEndian.PutUint16(frame[6:8], uint16(status))
+	} else {
+		binary.BigEndian.PutUint16(frame[6:8], 5)
+	}
+	return frame
+}
+
+func TestDcpFrames(t *testing.T) {
+	//flags, start, end, vbucket uuid, snapshot start and end
+	streamReq := dcpFrame(MAGIC_REQUEST, DCP_STREAM_REQ, 0, 0, 10, 100, 0xabcd, 10, 10)
+	accepted := dcpFrame(MAGIC_RESPONSE, DCP_STREAM_REQ, SUCCESS)
+	marker := dcpFrame(MAGIC_REQUEST, DCP_SNAPSHOT_MARKER, 0, 11, 20)
+	marker = append(marker[:headerLength+16], 0, 0, 0, 1)
+	binary.BigEndian.PutUint32(marker[8:12], 20)
+	marker[4] = 20
+	mutation := dcpFrame(MAGIC_REQUEST, DCP_MUTATION, 0, 15, 0)
+	shortExtras := append([]byte(nil), mutation[:headerLength+4]...)
+	shortExtras[4] = 4
+	binary.BigEndian.PutUint32(shortExtras[8:12], 4)
+	oversized := append([]byte(nil), mutation...)
+	binary.BigEndian.PutUint32(oversized[8:12], 0x02000001)
+	ack := encode(MAGIC_REQUEST, DCP_BUFFER_ACKNOWLEDGEMENT, 1, []byte{0, 0, 0, byte(len(marker) + len(mutation))}, "", nil)
+	tests := []struct {
+		name        string
+		fromServer  []bool
+		frames      [][]byte
+		dcpStream   *DcpStream
+		rollbacks   uint64
+		ackLag      int64
+		parseErrors map[ParseErrorKind]uint64
+	}{
+		{"stream request", []bool{false, true}, [][]byte{streamReq, accepted},
+			&DcpStream{Vbucket: 5, StartSeqno: 10, EndSeqno: 100, LastSeqno: 10}, 0, 0, nil},
+		{"rollback", []bool{false, true}, [][]byte{streamReq, dcpFrame(MAGIC_RESPONSE, DCP_STREAM_REQ, ROLLBACK)}, nil, 1,
+			0, nil},
+		{"snapshot", []bool{true, true}, [][]byte{marker, mutation}, &DcpStream{Vbucket: 5, LastSeqno: 15, Mutations: 1,
+			Snapshots: 1, SnapshotEnd: 20, TotalSnapshotSize: 10, MaxSnapshotSize: 10}, 0, 0, nil},
+		{"buffer ack", []bool{true, true, false}, [][]byte{marker, mutation, ack}, &DcpStream{Vbucket: 5, LastSeqno: 15,
+			Mutations: 1, Snapshots: 1, SnapshotEnd: 20, TotalSnapshotSize: 10, MaxSnapshotSize: 10}, 0, 2000, nil},
+		{"short extras", []bool{true}, [][]byte{shortExtras}, &DcpStream{Vbucket: 5, Mutations: 1}, 0, 0, nil},
+		{"truncated", []bool{true}, [][]byte{mutation[:len(mutation)-1]}, nil, 0, 0, nil},
+		{"oversized body length", []bool{true}, [][]byte{oversized}, nil, 0, 0,
+			map[ParseErrorKind]uint64{PARSE_ERROR_BODY_LENGTH: 1}},
+	}
+	clientFlow := gopacket.NewFlow(layers.EndpointTCPPort, []byte{0xc3, 0x50}, []byte{0x2b, 0xca})
+	for _, test := range tests {
+		stream := NewStream()
+		for i, frame := range test.frames {
+			direction := clientFlow
+			if test.fromServer[i] {
+				direction = clientFlow.Reverse()
+			}
+			stream.HandlePacket(direction, frame, int64(i+1)*1000)
+		}
+		var dcpStream *DcpStream
+		var rollbacks uint64
+		var ackLag int64
+		if stream.dcp != nil {
+			dcpStream = stream.dcp.Streams[dcpStreamKey{vbucket: 5}]
+			rollbacks, ackLag = stream.dcp.Rollbacks, stream.dcp.MaxAckLag
+		}
+		if !reflect.DeepEqual(dcpStream, test.dcpStream) {
+			t.Errorf("%v: expected stream %+v, got %+v", test.name, test.dcpStream, dcpStream)
+		}
+		if rollbacks != test.rollbacks || ackLag != test.ackLag {
+			t.Errorf("%v: expected %v rollbacks and an ack lag of %v, got %v and %v", test.name, test.rollbacks,
+				test.ackLag, rollbacks, ackLag)
+		}
+		if (len(test.parseErrors) > 0 || len(stream.parseErrors) > 0) && !reflect.DeepEqual(stream.parseErrors, test.parseErrors) {
+			t.Errorf("%v: expected parse errors %v, got %v", test.name, test.parseErrors, stream.parseErrors)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+/*
+* Copyright (c) 2017 Couchbase, Inc.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/binary"
+	"math"
+	"time"
+)
+
+//frame info identifiers carried in alt request (0x08) framing extras
+const (
+	frameBarrier                   = 0x00
+	frameDurabilityRequirement     = 0x01
+	frameDcpStreamId               = 0x02
+	frameOpenTracingContext        = 0x03
+	frameImpersonate               = 0x04
+	framePreserveTtl               = 0x05
+	frameImpersonateExtraPrivilege = 0x06
+)
+
+//frame info identifiers carried in alt response (0x18) framing extras
+const (
+	frameServerRecvSendDuration = 0x00
+	frameReadUnits              = 0x01
+	frameWriteUnits             = 0x02
+	frameThrottleDuration       = 0x03
+)
+
+type DurabilityLevel uint8
+
+const (
+	DURABILITY_NONE                           DurabilityLevel = 0x00
+	DURABILITY_MAJORITY                       DurabilityLevel = 0x01
+	DURABILITY_MAJORITY_AND_PERSIST_TO_ACTIVE DurabilityLevel = 0x02
+	DURABILITY_PERSIST_TO_MAJORITY            DurabilityLevel = 0x03
+)
+
+var durabilityLevelNames = map[DurabilityLevel]string{
+	DURABILITY_NONE:                           "",
+	DURABILITY_MAJORITY:                       "MAJORITY",
+	DURABILITY_MAJORITY_AND_PERSIST_TO_ACTIVE: "MAJORITY_AND_PERSIST_TO_ACTIVE",
+	DURABILITY_PERSIST_TO_MAJORITY:            "PERSIST_TO_MAJORITY",
+}
+
+func (level DurabilityLevel) String() string {
+	if name, ok := durabilityLevelNames[level]; ok {
+		return name
+	}
+	return "UNKNOWN"
+}
+
+//server encodes its recv->send duration as a 2 byte value, micros = encoded^1.74 / 2
+func decodeServerDuration(encoded uint16) int64 {
+	return int64(math.Pow(float64(encoded), 1.74) / 2 * float64(time.Microsecond))
+}
+
+//decodeFramingExtras walks the frame infos of the framing extras section. Each frame info starts with
+//an id:len nibble pair where 0xf in either nibble means the value continues in the next byte.
+func (c *Command) decodeFramingExtras() {
+	frames := c.framingExtras
+	for len(frames) > 0 {
+		id := uint16(frames[0] >> 4)
+		length := int(frames[0] & 0x0f)
+		offset := 1
+		if id == 0x0f {
+			if offset >= len(frames) {
+				return
+			}
+			id += uint16(frames[offset])
+			offset++
+		}
+		if length == 0x0f {
+			if offset >= len(frames) {
+				return
+			}
+			length += int(frames[offset])
+			offset++
+		}
+		if offset+length > len(frames) {
+			return
+		}
+		value := frames[offset : offset+length]
+		frames = frames[offset+length:]
+
+		if c.commandType == RESPONSE {
+			switch id {
+			case frameServerRecvSendDuration:
+				if length == 2 {
+					c.serverDuration = decodeServerDuration(binary.BigEndian.Uint16(value))
+					c.hasServerDuration = true
+				}
+			}
+		} else {
+			switch id {
+			case frameDurabilityRequirement:
+				if length >= 1 {
+					c.durabilityLevel = DurabilityLevel(value[0])
+				}
+			case frameDcpStreamId:
+				if length == 2 {
+					c.streamId = binary.BigEndian.Uint16(value)
+				}
+			case frameImpersonate:
+				c.impersonatedUser = string(value)
+			}
+		}
+	}
+}
@@ -0,0 +1,119 @@
+/*
+* Copyright (c) 2017 Couchbase, Inc.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/binary"
+	"io"
+	"testing"
+)
+
+func TestDecodeFramingExtras(t *testing.T) {
+	longUser := "couchbase-admin!"
+	tests := []struct {
+		name             string
+		commandType      CommandType
+		framingExtras    []byte
+		serverDuration   int64
+		durability       DurabilityLevel
+		streamId         uint16
+		impersonatedUser string
+	}{
+		{"server duration", RESPONSE, []byte{0x02, 0x00, 0x64}, 1509975, DURABILITY_NONE, 0, ""},
+		{"durability", REQUEST, []byte{0x11, 0x01}, -1, DURABILITY_MAJORITY, 0, ""},
+		{"durability with timeout", REQUEST, []byte{0x13, 0x03, 0x27, 0x10}, -1, DURABILITY_PERSIST_TO_MAJORITY, 0, ""},
+		{"stream id", REQUEST, []byte{0x22, 0x00, 0x07}, -1, DURABILITY_NONE, 7, ""},
+		{"impersonate", REQUEST, append([]byte{0x45}, "alice"...), -1, DURABILITY_NONE, 0, "alice"},
+		{"escaped length", REQUEST, append([]byte{0x4f, 0x01}, longUser...), -1, DURABILITY_NONE, 0, longUser},
+		{"several frames", REQUEST, append([]byte{0x11, 0x02, 0x22, 0x00, 0x03, 0x45}, "alice"...), -1,
+			DURABILITY_MAJORITY_AND_PERSIST_TO_ACTIVE, 3, "alice"},
+		{"escaped id is skipped", REQUEST, []byte{0xf1, 0x01, 0x00, 0x11, 0x01}, -1, DURABILITY_MAJORITY, 0, ""},
+		{"truncated value", RESPONSE, []byte{0x02, 0x00}, -1, DURABILITY_NONE, 0, ""},
+		{"truncated escaped id", REQUEST, []byte{0xf1}, -1, DURABILITY_NONE, 0, ""},
+		{"truncated escaped length", REQUEST, []byte{0x4f}, -1, DURABILITY_NONE, 0, ""},
+		{"oversized length", REQUEST, append([]byte{0x4f, 0xff}, "alice"...), -1, DURABILITY_NONE, 0, ""},
+		{"wrong server duration length", RESPONSE, []byte{0x01, 0x64}, -1, DURABILITY_NONE, 0, ""},
+		{"truncated second frame", REQUEST, []byte{0x11, 0x01, 0x22, 0x00}, -1, DURABILITY_MAJORITY, 0, ""},
+	}
+	for _, test := range tests {
+		command := &Command{commandType: test.commandType, framingExtras: test.framingExtras, serverDuration: -1}
+		command.decodeFramingExtras()
+		if command.serverDuration != test.serverDuration || command.durabilityLevel != test.durability ||
+			command.streamId != test.streamId || command.impersonatedUser != test.impersonatedUser {
+			t.Errorf("%v: expected %v/%v/%v/%q, got %v/%v/%v/%q", test.name, test.serverDuration, test.durability,
+				test.streamId, test.impersonatedUser, command.serverDuration, command.durabilityLevel, command.streamId,
+				command.impersonatedUser)
+		}
+		if command.hasServerDuration != (test.serverDuration >= 0) {
+			t.Errorf("%v: unexpected hasServerDuration %v", test.name, command.hasServerDuration)
+		}
+	}
+}
+
+//withFramingExtras turns a frame with a key shorter than 256 bytes into its alt magic form
+func withFramingExtras(frame []byte, framingExtras []byte) []byte {
+	alt := append([]byte(nil), frame[:24]...)
+	alt[0] = MAGIC_ALT_REQUEST
+	if frame[0] == MAGIC_RESPONSE {
+		alt[0] = MAGIC_ALT_RESPONSE
+	}
+	alt[2], alt[3] = byte(len(framingExtras)), frame[3]
+	binary.BigEndian.PutUint32(alt[8:12], binary.BigEndian.Uint32(frame[8:12])+uint32(len(framingExtras)))
+	alt = append(alt, framingExtras...)
+	return append(alt, frame[24:]...)
+}
+
+func TestAltMagicFrames(t *testing.T) {
+	set := withFramingExtras(encode(MAGIC_REQUEST, SET, 1, make([]byte, 8), "key", []byte("value")), []byte{0x11, 0x01})
+	response := withFramingExtras(encode(MAGIC_RESPONSE, GET, 2, []byte{0, 0, 0, 0}, "", []byte("value")),
+		[]byte{0x02, 0x00, 0x64})
+	oversizedFraming := append([]byte(nil), set...)
+	oversizedFraming[2] = 0xff
+	oversizedBody := append([]byte(nil), response...)
+	binary.BigEndian.PutUint32(oversizedBody[8:12], 0x02000001)
+	tests := []struct {
+		name           string
+		frame          []byte
+		err            error
+		key            string
+		serverDuration int64
+		durability     DurabilityLevel
+	}{
+		{"request", set, nil, "key", 0, DURABILITY_MAJORITY},
+		{"response", response, nil, "", 1509975, DURABILITY_NONE},
+		{"truncated header", set[:12], io.EOF, "", 0, DURABILITY_NONE},
+		{"truncated framing extras", set[:25], io.EOF, "", 0, DURABILITY_NONE},
+		{"truncated value", response[:len(response)-2], io.EOF, "", 1509975, DURABILITY_NONE},
+		{"oversized framing extras length", oversizedFraming, &ParseError{Kind: PARSE_ERROR_BODY_LENGTH}, "", 0, DURABILITY_NONE},
+		{"oversized body length", oversizedBody, &ParseError{Kind: PARSE_ERROR_BODY_LENGTH}, "", 0, DURABILITY_NONE},
+	}
+	for _, test := range tests {
+		command, err := decode(test.frame)
+		if !sameError(err, test.err) {
+			t.Errorf("%v: expected error %v, got %v", test.name, test.err, err)
+			continue
+		}
+		if command.isComplete() != (err == nil) {
+			t.Errorf("%v: unexpected isComplete %v", test.name, command.isComplete())
+		}
+		if string(command.key) != test.key || command.serverDuration != test.serverDuration ||
+			command.durabilityLevel != test.durability {
+			t.Errorf("%v: expected %q/%v/%v, got %q/%v/%v", test.name, test.key, test.serverDuration, test.durability,
+				command.key, command.serverDuration, command.durabilityLevel)
+		}
+	}
+}
\ No newline at end of file
@@ -0,0 +1,132 @@
+/*
+* Copyright (c) 2017 Couchbase, Inc.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type Feature uint16
+
+//features a client can negotiate with HELLO, see kv_engine include/mcbp/protocol/feature.h
+const (
+	FEATURE_DATATYPE                       Feature = 0x01
+	FEATURE_TLS                            Feature = 0x02
+	FEATURE_TCPNODELAY                     Feature = 0x03
+	FEATURE_MUTATION_SEQNO                 Feature = 0x04
+	FEATURE_TCPDELAY                       Feature = 0x05
+	FEATURE_XATTR                          Feature = 0x06
+	FEATURE_XERROR                         Feature = 0x07
+	FEATURE_SELECT_BUCKET                  Feature = 0x08
+	FEATURE_SNAPPY                         Feature = 0x0a
+	FEATURE_JSON                           Feature = 0x0b
+	FEATURE_DUPLEX                         Feature = 0x0c
+	FEATURE_CLUSTERMAP_CHANGE_NOTIFICATION Feature = 0x0d
+	FEATURE_UNORDERED_EXECUTION            Feature = 0x0e
+	FEATURE_TRACING                        Feature = 0x0f
+	FEATURE_ALT_REQUEST_SUPPORT            Feature = 0x10
+	FEATURE_SYNC_REPLICATION               Feature = 0x11
+	FEATURE_COLLECTIONS                    Feature = 0x12
+	FEATURE_OPEN_TRACING                   Feature = 0x13
+	FEATURE_PRESERVE_TTL                   Feature = 0x14
+	FEATURE_VATTR                          Feature = 0x15
+	FEATURE_POINT_IN_TIME_RECOVERY         Feature = 0x16
+	FEATURE_SUBDOC_CREATE_AS_DELETED       Feature = 0x17
+	FEATURE_SUBDOC_DOCUMENT_MACRO_SUPPORT  Feature = 0x18
+	FEATURE_SUBDOC_REPLACE_BODY_WITH_XATTR Feature = 0x19
+	FEATURE_REPORT_UNIT_USAGE              Feature = 0x1a
+	FEATURE_NON_BLOCKING_THROTTLING_MODE   Feature = 0x1b
+)
+
+var featureNames = map[Feature]string{
+	FEATURE_DATATYPE:                       "DATATYPE",
+	FEATURE_TLS:                            "TLS",
+	FEATURE_TCPNODELAY:                     "TCPNODELAY",
+	FEATURE_MUTATION_SEQNO:                 "MUTATION_SEQNO",
+	FEATURE_TCPDELAY:                       "TCPDELAY",
+	FEATURE_XATTR:                          "XATTR",
+	FEATURE_XERROR:                         "XERROR",
+	FEATURE_SELECT_BUCKET:                  "SELECT_BUCKET",
+	FEATURE_SNAPPY:                         "SNAPPY",
+	FEATURE_JSON:                           "JSON",
+	FEATURE_DUPLEX:                         "DUPLEX",
+	FEATURE_CLUSTERMAP_CHANGE_NOTIFICATION: "CLUSTERMAP_CHANGE_NOTIFICATION",
+	FEATURE_UNORDERED_EXECUTION:            "UNORDERED_EXECUTION",
+	FEATURE_TRACING:                        "TRACING",
+	FEATURE_ALT_REQUEST_SUPPORT:            "ALT_REQUEST_SUPPORT",
+	FEATURE_SYNC_REPLICATION:               "SYNC_REPLICATION",
+	FEATURE_COLLECTIONS:                    "COLLECTIONS",
+	FEATURE_OPEN_TRACING:                   "OPEN_TRACING",
+	FEATURE_PRESERVE_TTL:                   "PRESERVE_TTL",
+	FEATURE_VATTR:                          "VATTR",
+	FEATURE_POINT_IN_TIME_RECOVERY:         "POINT_IN_TIME_RECOVERY",
+	FEATURE_SUBDOC_CREATE_AS_DELETED:       "SUBDOC_CREATE_AS_DELETED",
+	FEATURE_SUBDOC_DOCUMENT_MACRO_SUPPORT:  "SUBDOC_DOCUMENT_MACRO_SUPPORT",
+	FEATURE_SUBDOC_REPLACE_BODY_WITH_XATTR: "SUBDOC_REPLACE_BODY_WITH_XATTR",
+	FEATURE_REPORT_UNIT_USAGE:              "REPORT_UNIT_USAGE",
+	FEATURE_NON_BLOCKING_THROTTLING_MODE:   "NON_BLOCKING_THROTTLING_MODE",
+}
+
+func (feature Feature) String() string {
+	if name, ok := featureNames[feature]; ok {
+		return name
+	}
+	return fmt.Sprintf("UNKNOWN_0x%04x", uint16(feature))
+}
+
+//decodeFeatures reads the list of 2 byte feature codes carried in the value of a HELLO command
+func decodeFeatures(value []byte) map[Feature]bool {
+	features := make(map[Feature]bool)
+	for ; len(value) >= 2; value = value[2:] {
+		features[Feature(binary.BigEndian.Uint16(value))] = true
+	}
+	return features
+}
+
+//decodeUserAgent reads the key of a HELLO request. Recent SDKs send {"a":"<agent>","i":"<connection id>"},
+//older ones the plain user agent string.
+func decodeUserAgent(key []byte) (userAgent string, connectionId string) {
+	var hello struct {
+		Agent        string `json:"a"`
+		ConnectionId string `json:"i"`
+	}
+	if err := json.Unmarshal(key, &hello); err == nil {
+		return hello.Agent, hello.ConnectionId
+	}
+	return string(key), ""
+}
+
+//sdkName returns the leading name/version token of a user agent such as "gocbcore/v10.2.0 gocb/v2.6.0"
+func sdkName(userAgent string) string {
+	if fields := strings.Fields(userAgent); len(fields) > 0 {
+		return fields[0]
+	}
+	return "unknown"
+}
+
+func featureNamesOf(features map[Feature]bool) []string {
+	var names []string
+	for feature := range features {
+		names = append(names, feature.String())
+	}
+	sort.Strings(names)
+	return names
+}
\ No newline at end of file
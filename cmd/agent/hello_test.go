@@ -0,0 +1,110 @@
+/*
+* Copyright (c) 2017 Couchbase, Inc.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestDecodeFeatures(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    []byte
+		features []Feature
+	}{
+		{"none", nil, nil},
+		{"one", []byte{0x00, 0x12}, []Feature{FEATURE_COLLECTIONS}},
+		{"several", []byte{0x00, 0x01, 0x00, 0x0b, 0x00, 0x10}, []Feature{FEATURE_DATATYPE, FEATURE_JSON, FEATURE_ALT_REQUEST_SUPPORT}},
+		{"unknown", []byte{0x01, 0x00}, []Feature{Feature(0x100)}},
+		{"truncated", []byte{0x00, 0x12, 0x00}, []Feature{FEATURE_COLLECTIONS}},
+	}
+	for _, test := range tests {
+		features := decodeFeatures(test.value)
+		if len(features) != len(test.features) {
+			t.Errorf("%v: expected %v, got %v", test.name, test.features, features)
+		}
+		for _, feature := range test.features {
+			if !features[feature] {
+				t.Errorf("%v: %v is missing from %v", test.name, feature, features)
+			}
+		}
+	}
+	if name := Feature(0x100).String(); name != "UNKNOWN_0x0100" {
+		t.Errorf("unexpected name %v for an unknown feature", name)
+	}
+}
+
+func TestDecodeUserAgent(t *testing.T) {
+	tests := []struct {
+		name         string
+		key          string
+		userAgent    string
+		connectionId string
+		sdk          string
+	}{
+		{"json", `{"a":"gocbcore/v10.2.0 gocb/v2.6.0","i":"0c6f0f2e/7a2b"}`, "gocbcore/v10.2.0 gocb/v2.6.0", "0c6f0f2e/7a2b", "gocbcore/v10.2.0"},
+		{"plain", "libcouchbase/3.3.7 (Linux)", "libcouchbase/3.3.7 (Linux)", "", "libcouchbase/3.3.7"},
+		{"truncated json", `{"a":"java`, `{"a":"java`, "", `{"a":"java`},
+		{"empty", "", "", "", "unknown"},
+	}
+	for _, test := range tests {
+		userAgent, connectionId := decodeUserAgent([]byte(test.key))
+		if userAgent != test.userAgent || connectionId != test.connectionId || sdkName(userAgent) != test.sdk {
+			t.Errorf("%v: expected %q, %q and sdk %q, got %q, %q and %q", test.name, test.userAgent, test.connectionId,
+				test.sdk, userAgent, connectionId, sdkName(userAgent))
+		}
+	}
+}
+
+func TestHelloFrames(t *testing.T) {
+	hello := encode(MAGIC_REQUEST, HELLO, 1, nil, `{"a":"pycbc/4.1.0"}`, []byte{0x00, 0x12, 0x00, 0x10})
+	accepted := encode(MAGIC_RESPONSE, HELLO, 1, nil, "", []byte{0x00, 0x12})
+	oversized := append([]byte(nil), hello...)
+	oversized[2], oversized[3] = 0x04, 0x01 //longer than any key
+	tests := []struct {
+		name      string
+		request   []byte
+		response  []byte
+		err       error
+		userAgent string
+		features  []string
+	}{
+		{"valid", hello, accepted, nil, "pycbc/4.1.0", []string{"COLLECTIONS"}},
+		{"truncated response", hello, accepted[:len(accepted)-1], io.EOF, "pycbc/4.1.0", nil},
+		{"oversized key length", oversized, accepted, &ParseError{Kind: PARSE_ERROR_KEY_LENGTH}, "", nil},
+	}
+	for _, test := range tests {
+		stream := NewStream()
+		request, err := decode(test.request)
+		if err == nil {
+			stream.handleSessionRequest(request)
+			var response *Command
+			if response, err = decode(test.response); err == nil {
+				stream.handleSessionResponse(request, response)
+			}
+		}
+		if !sameError(err, test.err) {
+			t.Errorf("%v: expected error %v, got %v", test.name, test.err, err)
+		}
+		if stream.userAgent != test.userAgent || !reflect.DeepEqual(featureNamesOf(stream.features), test.features) {
+			t.Errorf("%v: expected %q with %v, got %q with %v", test.name, test.userAgent, test.features,
+				stream.userAgent, featureNamesOf(stream.features))
+		}
+	}
+}
\ No newline at end of file
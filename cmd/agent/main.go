@@ -20,14 +20,17 @@ package main
 import (
 	"../../logger"
 	pb "../../rpc"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 	"gopkg.in/yaml.v2"
 	"io/ioutil"
 	"log"
 	"net"
+	"os"
 	"strings"
 	"sync"
 )
@@ -43,13 +46,36 @@ func loadConfig(configFile string, config *Config) {
 	}
 }
 
+//writeResults dumps the results of an offline analysis as JSON, to stdout when no file is given
+func writeResults(agent *Agent, outputFile string) {
+	results, err := agent.AgentResults(context.Background(), &pb.CoordinatorResultsRequest{})
+	if err != nil {
+		log.Fatalf("Error while collecting results: %v", err)
+	}
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		log.Fatalf("Error while encoding results: %v", err)
+	}
+	if outputFile == "" {
+		os.Stdout.Write(data)
+		return
+	}
+	if err := ioutil.WriteFile(outputFile, data, 0644); err != nil {
+		log.Fatalf("Error while writing results: %v", err)
+	}
+}
+
 func main() {
 	configFile := flag.String("config", "config.yml", "Config file for the tricorder agent")
+	pcapFile := flag.String("pcap", "", "Analyze a pcap or pcapng file instead of capturing live")
+	outputFile := flag.String("output", "", "File to write the results of -pcap to, defaults to stdout")
+	serve := flag.Bool("serve", false, "Serve the results of -pcap to a coordinator instead of writing them out")
 	flag.Parse()
 	agent := &Agent{
-		config: &Config{},
-		mutex:  &sync.Mutex{},
-		logger: &logger.Logger{},
+		config:      &Config{},
+		mutex:       &sync.Mutex{},
+		signalMutex: &sync.Mutex{},
+		logger:      &logger.Logger{},
 	}
 	loadConfig(fmt.Sprint("./", *configFile), agent.config)
 
@@ -61,6 +87,17 @@ func main() {
 		agent.logger.Init(agent.config.logging.file, 2)
 	}
 
+	if *pcapFile != "" {
+		agent.config.useCaptureFile(*pcapFile)
+		agent.Initialize()
+		agent.logger.Info("Analyzing %v", *pcapFile)
+		agent.startCapture(agent.newCapture())
+		if !*serve {
+			writeResults(agent, *outputFile)
+			return
+		}
+	}
+
 	agent.logger.Info("Starting the agent at %v", agent.config.Port)
 
 	lis, err := net.Listen("tcp", fmt.Sprint(":", agent.config.Port))
@@ -69,7 +106,9 @@ func main() {
 	}
 	s := grpc.NewServer()
 
-	agent.Initialize()
+	if *pcapFile == "" {
+		agent.Initialize()
+	}
 	pb.RegisterAgentServiceServer(s, agent)
 	// Register reflection service on gRPC server.
 	reflection.Register(s)
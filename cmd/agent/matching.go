@@ -0,0 +1,94 @@
+/*
+* Copyright (c) 2017 Couchbase, Inc.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package main
+
+//requestQueue holds the outstanding requests sent in one direction of a connection
+type requestQueue struct {
+	queue       []*Command            //in the order they were sent, answered requests are dropped from the head lazily
+	byOpaque    map[uint32][]*Command //oldest first, clients may reuse an opaque before it was answered
+	outstanding int
+}
+
+func newRequestQueue() *requestQueue {
+	return &requestQueue{
+		byOpaque: make(map[uint32][]*Command),
+	}
+}
+
+func (q *requestQueue) push(request *Command) {
+	q.queue = append(q.queue, request)
+	q.byOpaque[request.opaque] = append(q.byOpaque[request.opaque], request)
+	q.outstanding++
+}
+
+//match returns the oldest outstanding request with the opaque and opcode of the response
+func (q *requestQueue) match(response *Command) *Command {
+	for _, request := range q.byOpaque[response.opaque] {
+		if request.opcode == response.opcode {
+			return request
+		}
+	}
+	return nil
+}
+
+func (q *requestQueue) hasOpaque(opaque uint32) bool {
+	return len(q.byOpaque[opaque]) > 0
+}
+
+func (q *requestQueue) remove(request *Command) {
+	requests := q.byOpaque[request.opaque]
+	for i, candidate := range requests {
+		if candidate == request {
+			requests = append(requests[:i:i], requests[i+1:]...)
+			break
+		}
+	}
+	if len(requests) == 0 {
+		delete(q.byOpaque, request.opaque)
+	} else {
+		q.byOpaque[request.opaque] = requests
+	}
+	request.answered = true
+	q.outstanding--
+
+	for len(q.queue) > 0 && q.queue[0].answered {
+		q.queue[0] = nil
+		q.queue = q.queue[1:]
+	}
+}
+
+//oldestAnswerable returns the oldest outstanding request which the server has to answer, in
+//order execution answers it before any later request
+func (q *requestQueue) oldestAnswerable() *Command {
+	for _, request := range q.queue {
+		if !request.answered && !request.opcode.isQuiet() {
+			return request
+		}
+	}
+	return nil
+}
+
+//pending returns the outstanding requests in the order they were sent
+func (q *requestQueue) pending() []*Command {
+	var requests []*Command
+	for _, request := range q.queue {
+		if !request.answered {
+			requests = append(requests, request)
+		}
+	}
+	return requests
+}
\ No newline at end of file
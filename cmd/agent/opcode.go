@@ -0,0 +1,344 @@
+/*
+* Copyright (c) 2017 Couchbase, Inc.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package main
+
+import "fmt"
+
+type Opcode uint8
+
+//memcached binary protocol client opcodes, see kv_engine include/mcbp/protocol/opcode.h
+const (
+	GET                        Opcode = 0x00
+	SET                        Opcode = 0x01
+	ADD                        Opcode = 0x02
+	REPLACE                    Opcode = 0x03
+	DELETE                     Opcode = 0x04
+	INCREMENT                  Opcode = 0x05
+	DECREMENT                  Opcode = 0x06
+	QUIT                       Opcode = 0x07
+	FLUSH                      Opcode = 0x08
+	GETQ                       Opcode = 0x09
+	NOOP                       Opcode = 0x0a
+	VERSION                    Opcode = 0x0b
+	GETK                       Opcode = 0x0c
+	GETKQ                      Opcode = 0x0d
+	APPEND                     Opcode = 0x0e
+	PREPEND                    Opcode = 0x0f
+	STAT                       Opcode = 0x10
+	SETQ                       Opcode = 0x11
+	ADDQ                       Opcode = 0x12
+	REPLACEQ                   Opcode = 0x13
+	DELETEQ                    Opcode = 0x14
+	INCREMENTQ                 Opcode = 0x15
+	DECREMENTQ                 Opcode = 0x16
+	QUITQ                      Opcode = 0x17
+	FLUSHQ                     Opcode = 0x18
+	APPENDQ                    Opcode = 0x19
+	PREPENDQ                   Opcode = 0x1a
+	VERBOSITY                  Opcode = 0x1b
+	TOUCH                      Opcode = 0x1c
+	GAT                        Opcode = 0x1d
+	GATQ                       Opcode = 0x1e
+	HELLO                      Opcode = 0x1f
+	SASL_LIST_MECHS            Opcode = 0x20
+	SASL_AUTH                  Opcode = 0x21
+	SASL_STEP                  Opcode = 0x22
+	IOCTL_GET                  Opcode = 0x23
+	IOCTL_SET                  Opcode = 0x24
+	CONFIG_VALIDATE            Opcode = 0x25
+	CONFIG_RELOAD              Opcode = 0x26
+	AUDIT_PUT                  Opcode = 0x27
+	AUDIT_CONFIG_RELOAD        Opcode = 0x28
+	SHUTDOWN                   Opcode = 0x29
+	SET_VBUCKET                Opcode = 0x3d
+	GET_VBUCKET                Opcode = 0x3e
+	DEL_VBUCKET                Opcode = 0x3f
+	GET_ALL_VB_SEQNOS          Opcode = 0x48
+	DCP_OPEN                   Opcode = 0x50
+	DCP_ADD_STREAM             Opcode = 0x51
+	DCP_CLOSE_STREAM           Opcode = 0x52
+	DCP_STREAM_REQ             Opcode = 0x53
+	DCP_GET_FAILOVER_LOG       Opcode = 0x54
+	DCP_STREAM_END             Opcode = 0x55
+	DCP_SNAPSHOT_MARKER        Opcode = 0x56
+	DCP_MUTATION               Opcode = 0x57
+	DCP_DELETION               Opcode = 0x58
+	DCP_EXPIRATION             Opcode = 0x59
+	DCP_SET_VBUCKET_STATE      Opcode = 0x5b
+	DCP_NOOP                   Opcode = 0x5c
+	DCP_BUFFER_ACKNOWLEDGEMENT Opcode = 0x5d
+	DCP_CONTROL                Opcode = 0x5e
+	DCP_SYSTEM_EVENT           Opcode = 0x5f
+	DCP_PREPARE                Opcode = 0x60
+	DCP_SEQNO_ACKNOWLEDGED     Opcode = 0x61
+	DCP_COMMIT                 Opcode = 0x62
+	DCP_ABORT                  Opcode = 0x63
+	DCP_SEQNO_ADVANCED         Opcode = 0x64
+	DCP_OSO_SNAPSHOT           Opcode = 0x65
+	STOP_PERSISTENCE           Opcode = 0x80
+	START_PERSISTENCE          Opcode = 0x81
+	SET_PARAM                  Opcode = 0x82
+	GET_REPLICA                Opcode = 0x83
+	CREATE_BUCKET              Opcode = 0x85
+	DELETE_BUCKET              Opcode = 0x86
+	LIST_BUCKETS               Opcode = 0x87
+	SELECT_BUCKET              Opcode = 0x89
+	OBSERVE_SEQNO              Opcode = 0x91
+	OBSERVE                    Opcode = 0x92
+	EVICT_KEY                  Opcode = 0x93
+	GET_LOCKED                 Opcode = 0x94
+	UNLOCK_KEY                 Opcode = 0x95
+	GET_FAILOVER_LOG           Opcode = 0x96
+	LAST_CLOSED_CHECKPOINT     Opcode = 0x97
+	GET_META                   Opcode = 0xa0
+	GETQ_META                  Opcode = 0xa1
+	SET_WITH_META              Opcode = 0xa2
+	SETQ_WITH_META             Opcode = 0xa3
+	ADD_WITH_META              Opcode = 0xa4
+	ADDQ_WITH_META             Opcode = 0xa5
+	SNAPSHOT_VB_STATES         Opcode = 0xa6
+	VBUCKET_BATCH_COUNT        Opcode = 0xa7
+	DEL_WITH_META              Opcode = 0xa8
+	DELQ_WITH_META             Opcode = 0xa9
+	CREATE_CHECKPOINT          Opcode = 0xaa
+	NOTIFY_VBUCKET_UPDATE      Opcode = 0xac
+	ENABLE_TRAFFIC             Opcode = 0xad
+	DISABLE_TRAFFIC            Opcode = 0xae
+	CHANGE_VB_FILTER           Opcode = 0xb0
+	CHECKPOINT_PERSISTENCE     Opcode = 0xb1
+	RETURN_META                Opcode = 0xb2
+	COMPACT_DB                 Opcode = 0xb3
+	SET_CLUSTER_CONFIG         Opcode = 0xb4
+	GET_CLUSTER_CONFIG         Opcode = 0xb5
+	GET_RANDOM_KEY             Opcode = 0xb6
+	SEQNO_PERSISTENCE          Opcode = 0xb7
+	GET_KEYS                   Opcode = 0xb8
+	COLLECTIONS_SET_MANIFEST   Opcode = 0xb9
+	COLLECTIONS_GET_MANIFEST   Opcode = 0xba
+	COLLECTIONS_GET_ID         Opcode = 0xbb
+	COLLECTIONS_GET_SCOPE_ID   Opcode = 0xbc
+	SET_DRIFT_COUNTER_STATE    Opcode = 0xc1
+	GET_ADJUSTED_TIME          Opcode = 0xc2
+	SUBDOC_GET                 Opcode = 0xc5
+	SUBDOC_EXISTS              Opcode = 0xc6
+	SUBDOC_DICT_ADD            Opcode = 0xc7
+	SUBDOC_DICT_UPSERT         Opcode = 0xc8
+	SUBDOC_DELETE              Opcode = 0xc9
+	SUBDOC_REPLACE             Opcode = 0xca
+	SUBDOC_ARRAY_PUSH_LAST     Opcode = 0xcb
+	SUBDOC_ARRAY_PUSH_FIRST    Opcode = 0xcc
+	SUBDOC_ARRAY_INSERT        Opcode = 0xcd
+	SUBDOC_ARRAY_ADD_UNIQUE    Opcode = 0xce
+	SUBDOC_COUNTER             Opcode = 0xcf
+	SUBDOC_MULTI_LOOKUP        Opcode = 0xd0
+	SUBDOC_MULTI_MUTATION      Opcode = 0xd1
+	SUBDOC_GET_COUNT           Opcode = 0xd2
+	SUBDOC_REPLACE_BODY_XATTR  Opcode = 0xd3
+	RANGE_SCAN_CREATE          Opcode = 0xda
+	RANGE_SCAN_CONTINUE        Opcode = 0xdb
+	RANGE_SCAN_CANCEL          Opcode = 0xdc
+	SCRUB                      Opcode = 0xf0
+	ISASL_REFRESH              Opcode = 0xf1
+	SSL_CERTS_REFRESH          Opcode = 0xf2
+	GET_CMD_TIMER              Opcode = 0xf3
+	SET_CTRL_TOKEN             Opcode = 0xf4
+	GET_CTRL_TOKEN             Opcode = 0xf5
+	UPDATE_EXTERNAL_USER_PERMS Opcode = 0xf6
+	RBAC_REFRESH               Opcode = 0xf7
+	AUTH_PROVIDER              Opcode = 0xf8
+	DROP_PRIVILEGE             Opcode = 0xfb
+	ADJUST_TIMEOFDAY           Opcode = 0xfc
+	EWOULDBLOCK_CTL            Opcode = 0xfd
+	GET_ERROR_MAP              Opcode = 0xfe
+)
+
+var opcodeNames = map[Opcode]string{
+	GET:                        "GET",
+	SET:                        "SET",
+	ADD:                        "ADD",
+	REPLACE:                    "REPLACE",
+	DELETE:                     "DELETE",
+	INCREMENT:                  "INCREMENT",
+	DECREMENT:                  "DECREMENT",
+	QUIT:                       "QUIT",
+	FLUSH:                      "FLUSH",
+	GETQ:                       "GETQ",
+	NOOP:                       "NOOP",
+	VERSION:                    "VERSION",
+	GETK:                       "GETK",
+	GETKQ:                      "GETKQ",
+	APPEND:                     "APPEND",
+	PREPEND:                    "PREPEND",
+	STAT:                       "STAT",
+	SETQ:                       "SETQ",
+	ADDQ:                       "ADDQ",
+	REPLACEQ:                   "REPLACEQ",
+	DELETEQ:                    "DELETEQ",
+	INCREMENTQ:                 "INCREMENTQ",
+	DECREMENTQ:                 "DECREMENTQ",
+	QUITQ:                      "QUITQ",
+	FLUSHQ:                     "FLUSHQ",
+	APPENDQ:                    "APPENDQ",
+	PREPENDQ:                   "PREPENDQ",
+	VERBOSITY:                  "VERBOSITY",
+	TOUCH:                      "TOUCH",
+	GAT:                        "GAT",
+	GATQ:                       "GATQ",
+	HELLO:                      "HELLO",
+	SASL_LIST_MECHS:            "SASL_LIST_MECHS",
+	SASL_AUTH:                  "SASL_AUTH",
+	SASL_STEP:                  "SASL_STEP",
+	IOCTL_GET:                  "IOCTL_GET",
+	IOCTL_SET:                  "IOCTL_SET",
+	CONFIG_VALIDATE:            "CONFIG_VALIDATE",
+	CONFIG_RELOAD:              "CONFIG_RELOAD",
+	AUDIT_PUT:                  "AUDIT_PUT",
+	AUDIT_CONFIG_RELOAD:        "AUDIT_CONFIG_RELOAD",
+	SHUTDOWN:                   "SHUTDOWN",
+	SET_VBUCKET:                "SET_VBUCKET",
+	GET_VBUCKET:                "GET_VBUCKET",
+	DEL_VBUCKET:                "DEL_VBUCKET",
+	GET_ALL_VB_SEQNOS:          "GET_ALL_VB_SEQNOS",
+	DCP_OPEN:                   "DCP_OPEN",
+	DCP_ADD_STREAM:             "DCP_ADD_STREAM",
+	DCP_CLOSE_STREAM:           "DCP_CLOSE_STREAM",
+	DCP_STREAM_REQ:             "DCP_STREAM_REQ",
+	DCP_GET_FAILOVER_LOG:       "DCP_GET_FAILOVER_LOG",
+	DCP_STREAM_END:             "DCP_STREAM_END",
+	DCP_SNAPSHOT_MARKER:        "DCP_SNAPSHOT_MARKER",
+	DCP_MUTATION:               "DCP_MUTATION",
+	DCP_DELETION:               "DCP_DELETION",
+	DCP_EXPIRATION:             "DCP_EXPIRATION",
+	DCP_SET_VBUCKET_STATE:      "DCP_SET_VBUCKET_STATE",
+	DCP_NOOP:                   "DCP_NOOP",
+	DCP_BUFFER_ACKNOWLEDGEMENT: "DCP_BUFFER_ACKNOWLEDGEMENT",
+	DCP_CONTROL:                "DCP_CONTROL",
+	DCP_SYSTEM_EVENT:           "DCP_SYSTEM_EVENT",
+	DCP_PREPARE:                "DCP_PREPARE",
+	DCP_SEQNO_ACKNOWLEDGED:     "DCP_SEQNO_ACKNOWLEDGED",
+	DCP_COMMIT:                 "DCP_COMMIT",
+	DCP_ABORT:                  "DCP_ABORT",
+	DCP_SEQNO_ADVANCED:         "DCP_SEQNO_ADVANCED",
+	DCP_OSO_SNAPSHOT:           "DCP_OSO_SNAPSHOT",
+	STOP_PERSISTENCE:           "STOP_PERSISTENCE",
+	START_PERSISTENCE:          "START_PERSISTENCE",
+	SET_PARAM:                  "SET_PARAM",
+	GET_REPLICA:                "GET_REPLICA",
+	CREATE_BUCKET:              "CREATE_BUCKET",
+	DELETE_BUCKET:              "DELETE_BUCKET",
+	LIST_BUCKETS:               "LIST_BUCKETS",
+	SELECT_BUCKET:              "SELECT_BUCKET",
+	OBSERVE_SEQNO:              "OBSERVE_SEQNO",
+	OBSERVE:                    "OBSERVE",
+	EVICT_KEY:                  "EVICT_KEY",
+	GET_LOCKED:                 "GET_LOCKED",
+	UNLOCK_KEY:                 "UNLOCK_KEY",
+	GET_FAILOVER_LOG:           "GET_FAILOVER_LOG",
+	LAST_CLOSED_CHECKPOINT:     "LAST_CLOSED_CHECKPOINT",
+	GET_META:                   "GET_META",
+	GETQ_META:                  "GETQ_META",
+	SET_WITH_META:              "SET_WITH_META",
+	SETQ_WITH_META:             "SETQ_WITH_META",
+	ADD_WITH_META:              "ADD_WITH_META",
+	ADDQ_WITH_META:             "ADDQ_WITH_META",
+	SNAPSHOT_VB_STATES:         "SNAPSHOT_VB_STATES",
+	VBUCKET_BATCH_COUNT:        "VBUCKET_BATCH_COUNT",
+	DEL_WITH_META:              "DEL_WITH_META",
+	DELQ_WITH_META:             "DELQ_WITH_META",
+	CREATE_CHECKPOINT:          "CREATE_CHECKPOINT",
+	NOTIFY_VBUCKET_UPDATE:      "NOTIFY_VBUCKET_UPDATE",
+	ENABLE_TRAFFIC:             "ENABLE_TRAFFIC",
+	DISABLE_TRAFFIC:            "DISABLE_TRAFFIC",
+	CHANGE_VB_FILTER:           "CHANGE_VB_FILTER",
+	CHECKPOINT_PERSISTENCE:     "CHECKPOINT_PERSISTENCE",
+	RETURN_META:                "RETURN_META",
+	COMPACT_DB:                 "COMPACT_DB",
+	SET_CLUSTER_CONFIG:         "SET_CLUSTER_CONFIG",
+	GET_CLUSTER_CONFIG:         "GET_CLUSTER_CONFIG",
+	GET_RANDOM_KEY:             "GET_RANDOM_KEY",
+	SEQNO_PERSISTENCE:          "SEQNO_PERSISTENCE",
+	GET_KEYS:                   "GET_KEYS",
+	COLLECTIONS_SET_MANIFEST:   "COLLECTIONS_SET_MANIFEST",
+	COLLECTIONS_GET_MANIFEST:   "COLLECTIONS_GET_MANIFEST",
+	COLLECTIONS_GET_ID:         "COLLECTIONS_GET_ID",
+	COLLECTIONS_GET_SCOPE_ID:   "COLLECTIONS_GET_SCOPE_ID",
+	SET_DRIFT_COUNTER_STATE:    "SET_DRIFT_COUNTER_STATE",
+	GET_ADJUSTED_TIME:          "GET_ADJUSTED_TIME",
+	SUBDOC_GET:                 "SUBDOC_GET",
+	SUBDOC_EXISTS:              "SUBDOC_EXISTS",
+	SUBDOC_DICT_ADD:            "SUBDOC_DICT_ADD",
+	SUBDOC_DICT_UPSERT:         "SUBDOC_DICT_UPSERT",
+	SUBDOC_DELETE:              "SUBDOC_DELETE",
+	SUBDOC_REPLACE:             "SUBDOC_REPLACE",
+	SUBDOC_ARRAY_PUSH_LAST:     "SUBDOC_ARRAY_PUSH_LAST",
+	SUBDOC_ARRAY_PUSH_FIRST:    "SUBDOC_ARRAY_PUSH_FIRST",
+	SUBDOC_ARRAY_INSERT:        "SUBDOC_ARRAY_INSERT",
+	SUBDOC_ARRAY_ADD_UNIQUE:    "SUBDOC_ARRAY_ADD_UNIQUE",
+	SUBDOC_COUNTER:             "SUBDOC_COUNTER",
+	SUBDOC_MULTI_LOOKUP:        "SUBDOC_MULTI_LOOKUP",
+	SUBDOC_MULTI_MUTATION:      "SUBDOC_MULTI_MUTATION",
+	SUBDOC_GET_COUNT:           "SUBDOC_GET_COUNT",
+	SUBDOC_REPLACE_BODY_XATTR:  "SUBDOC_REPLACE_BODY_WITH_XATTR",
+	RANGE_SCAN_CREATE:          "RANGE_SCAN_CREATE",
+	RANGE_SCAN_CONTINUE:        "RANGE_SCAN_CONTINUE",
+	RANGE_SCAN_CANCEL:          "RANGE_SCAN_CANCEL",
+	SCRUB:                      "SCRUB",
+	ISASL_REFRESH:              "ISASL_REFRESH",
+	SSL_CERTS_REFRESH:          "SSL_CERTS_REFRESH",
+	GET_CMD_TIMER:              "GET_CMD_TIMER",
+	SET_CTRL_TOKEN:             "SET_CTRL_TOKEN",
+	GET_CTRL_TOKEN:             "GET_CTRL_TOKEN",
+	UPDATE_EXTERNAL_USER_PERMS: "UPDATE_EXTERNAL_USER_PERMISSIONS",
+	RBAC_REFRESH:               "RBAC_REFRESH",
+	AUTH_PROVIDER:              "AUTH_PROVIDER",
+	DROP_PRIVILEGE:             "DROP_PRIVILEGE",
+	ADJUST_TIMEOFDAY:           "ADJUST_TIMEOFDAY",
+	EWOULDBLOCK_CTL:            "EWOULDBLOCK_CTL",
+	GET_ERROR_MAP:              "GET_ERROR_MAP",
+}
+
+func (op Opcode) String() string {
+	if name, ok := opcodeNames[op]; ok {
+		return name
+	}
+	return fmt.Sprintf("UNKNOWN_0x%02x", uint8(op))
+}
+
+func (op Opcode) isKnown() bool {
+	_, ok := opcodeNames[op]
+	return ok
+}
+
+//isQuiet tells whether the server omits the response of the opcode unless there is something
+// to report, a quiet get is only answered on a hit and quiet mutations only on errors
+func (op Opcode) isQuiet() bool {
+	switch op {
+	case GETQ, GETKQ, SETQ, ADDQ, REPLACEQ, DELETEQ, INCREMENTQ, DECREMENTQ, QUITQ, FLUSHQ, APPENDQ, PREPENDQ,
+		GATQ, GETQ_META, SETQ_WITH_META, ADDQ_WITH_META, DELQ_WITH_META:
+		return true
+	}
+	return false
+}
+
+//quietStatus is the status implied by the missing response of a quiet opcode
+func (op Opcode) quietStatus() Status {
+	switch op {
+	case GETQ, GETKQ, GATQ, GETQ_META:
+		return KEY_ENOENT
+	}
+	return SUCCESS
+}
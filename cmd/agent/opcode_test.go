@@ -0,0 +1,128 @@
+/*
+* Copyright (c) 2017 Couchbase, Inc.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+)
+
+//encode builds a binary protocol frame
+func encode(magic byte, opcode Opcode, opaque uint32, extras []byte, key string, value []byte) []byte {
+	frame := make([]byte, 24)
+	frame[0] = magic
+	frame[1] = byte(opcode)
+	binary.BigEndian.PutUint16(frame[2:4], uint16(len(key)))
+	frame[4] = byte(len(extras))
+	binary.BigEndian.PutUint32(frame[8:12], uint32(len(extras)+len(key)+len(value)))
+	binary.BigEndian.PutUint32(frame[12:16], opaque)
+	frame = append(frame, extras...)
+	frame = append(frame, key...)
+	return append(frame, value...)
+}
+
+//decode parses data as a single command, io.EOF means data ended before the command did
+func decode(data []byte) (*Command, error) {
+	command := NewCommand(0)
+	if err := command.ReadNewPacketData(bytes.NewBuffer(data)); err != nil {
+		return command, err
+	}
+	return command, nil
+}
+
+//sameError compares parse errors by their kind only
+func sameError(err error, expected error) bool {
+	if parseError, ok := err.(*ParseError); ok {
+		expectedParseError, ok := expected.(*ParseError)
+		return ok && parseError.Kind == expectedParseError.Kind
+	}
+	return err == expected
+}
+
+func TestOpcodeNames(t *testing.T) {
+	tests := []struct {
+		opcode Opcode
+		name   string
+	}{
+		{GET, "GET"},
+		{GETQ, "GETQ"},
+		{NOOP, "NOOP"},
+		{HELLO, "HELLO"},
+		{SASL_AUTH, "SASL_AUTH"},
+		{SUBDOC_REPLACE_BODY_XATTR, "SUBDOC_REPLACE_BODY_WITH_XATTR"},
+		{RANGE_SCAN_CANCEL, "RANGE_SCAN_CANCEL"},
+		{GET_ERROR_MAP, "GET_ERROR_MAP"},
+		{Opcode(0x2f), "UNKNOWN_0x2f"},
+		{Opcode(0xff), "UNKNOWN_0xff"},
+	}
+	for _, test := range tests {
+		if name := test.opcode.String(); name != test.name {
+			t.Errorf("expected %v for 0x%02x, got %v", test.name, uint8(test.opcode), name)
+		}
+	}
+}
+
+func TestOpcodeNamesAreUnique(t *testing.T) {
+	opcodes := make(map[string]Opcode)
+	for opcode, name := range opcodeNames {
+		if other, ok := opcodes[name]; ok {
+			t.Errorf("0x%02x and 0x%02x are both named %v", uint8(opcode), uint8(other), name)
+		}
+		opcodes[name] = opcode
+	}
+}
+
+func TestOpcodeFrames(t *testing.T) {
+	get := encode(0x80, GET, 1, nil, "key", nil)
+	//one lookup spec: opcode, flags, path length and path
+	lookup := encode(0x80, SUBDOC_MULTI_LOOKUP, 2, []byte{0}, "doc", []byte{0xc5, 0, 0, 1, 'a'})
+	unknown := encode(0x81, Opcode(0x2f), 3, []byte{0, 0, 0, 0}, "", []byte("value"))
+	oversized := append([]byte(nil), get...)
+	binary.BigEndian.PutUint32(oversized[8:12], 0x02000001)
+	tests := []struct {
+		name   string
+		frame  []byte
+		err    error
+		opcode string
+		key    string
+	}{
+		{"get", get, nil, "GET", "key"},
+		{"subdoc lookup", lookup, nil, "SUBDOC_MULTI_LOOKUP", "doc"},
+		{"unknown opcode", unknown, nil, "UNKNOWN_0x2f", ""},
+		{"truncated header", get[:10], io.EOF, "", ""},
+		{"truncated key", get[:len(get)-1], io.EOF, "", ""},
+		{"oversized body length", oversized, &ParseError{Kind: PARSE_ERROR_BODY_LENGTH}, "", ""},
+	}
+	for _, test := range tests {
+		command, err := decode(test.frame)
+		if !sameError(err, test.err) {
+			t.Errorf("%v: expected error %v, got %v", test.name, test.err, err)
+			continue
+		}
+		if err != nil {
+			if command.isComplete() {
+				t.Errorf("%v: command is complete without its body", test.name)
+			}
+			continue
+		}
+		if name := command.opcode.String(); name != test.opcode || string(command.key) != test.key {
+			t.Errorf("%v: expected %v %q, got %v %q", test.name, test.opcode, test.key, name, command.key)
+		}
+	}
+}
\ No newline at end of file
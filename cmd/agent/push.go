@@ -0,0 +1,88 @@
+/*
+* Copyright (c) 2017 Couchbase, Inc.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package main
+
+import "fmt"
+
+//ServerOpcode is the opcode of a request the server pushes to a client which negotiated duplex
+type ServerOpcode uint8
+
+const (
+	CLUSTERMAP_CHANGE_NOTIFICATION ServerOpcode = 0x01
+	AUTHENTICATE                   ServerOpcode = 0x02
+	ACTIVE_EXTERNAL_USERS          ServerOpcode = 0x03
+	GET_AUTHORIZATION              ServerOpcode = 0x04
+)
+
+var serverOpcodeNames = map[ServerOpcode]string{
+	CLUSTERMAP_CHANGE_NOTIFICATION: "CLUSTERMAP_CHANGE_NOTIFICATION",
+	AUTHENTICATE:                   "AUTHENTICATE",
+	ACTIVE_EXTERNAL_USERS:          "ACTIVE_EXTERNAL_USERS",
+	GET_AUTHORIZATION:              "GET_AUTHORIZATION",
+}
+
+func (op ServerOpcode) String() string {
+	if name, ok := serverOpcodeNames[op]; ok {
+		return name
+	}
+	return fmt.Sprintf("UNKNOWN_SERVER_0x%02x", uint8(op))
+}
+
+func (op ServerOpcode) isKnown() bool {
+	_, ok := serverOpcodeNames[op]
+	return ok
+}
+
+//PushMessage is a server request and the client response to it, if the client sent one
+type PushMessage struct {
+	Opcode    ServerOpcode
+	Timestamp int64
+	Latency   int64 //-1 while unanswered
+	Status    Status
+	Key       string
+}
+
+//handleServerRequest records a message pushed by the server, a clustermap change notification
+//carries the new cluster map of the bucket named by its key
+func (stream *Stream) handleServerRequest(request *Command) {
+	opcode := request.serverOpcode()
+	if opcode == CLUSTERMAP_CHANGE_NOTIFICATION {
+		stream.handleClusterConfig(string(request.key), request, opcode.String())
+	}
+	if len(stream.pushMessages) >= maxUnansweredOps {
+		stream.droppedPushes++
+		return
+	}
+	stream.pendingPushes[request.opaque] = len(stream.pushMessages)
+	stream.pushMessages = append(stream.pushMessages, PushMessage{
+		Opcode:    opcode,
+		Timestamp: request.captureTimeInNanos,
+		Latency:   -1,
+		Key:       string(request.key),
+	})
+}
+
+func (stream *Stream) handleClientResponse(response *Command) {
+	index, ok := stream.pendingPushes[response.opaque]
+	if !ok {
+		return
+	}
+	delete(stream.pendingPushes, response.opaque)
+	message := &stream.pushMessages[index]
+	message.Latency = response.captureTimeInNanos - message.Timestamp
+	message.Status = response.status
+}
\ No newline at end of file
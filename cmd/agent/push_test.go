@@ -0,0 +1,83 @@
+/*
+* Copyright (c) 2017 Couchbase, Inc.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/binary"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"reflect"
+	"testing"
+)
+
+func TestPushFrames(t *testing.T) {
+	config := `{"rev":12,"revEpoch":1,"name":"travel","nodesExt":[{"hostname":"10.0.0.3","services":{"kv":11210}}]}`
+	authenticate := encode(MAGIC_SERVER_REQUEST, Opcode(AUTHENTICATE), 7, nil, "", []byte("challenge"))
+	answer := encode(MAGIC_CLIENT_RESPONSE, Opcode(AUTHENTICATE), 7, nil, "", nil)
+	binary.BigEndian.PutUint16(answer[6:8], uint16(EACCESS))
+	oversized := append([]byte(nil), authenticate...)
+	binary.BigEndian.PutUint16(oversized[2:4], 0x0401)
+	tests := []struct {
+		name        string
+		push        []byte
+		answer      []byte
+		messages    []PushMessage
+		revisions   int
+		parseErrors map[ParseErrorKind]uint64
+	}{
+		{"answered", authenticate, answer, []PushMessage{{Opcode: AUTHENTICATE, Timestamp: 1000, Latency: 500,
+			Status: EACCESS}}, 0, nil},
+		{"unanswered", authenticate, nil, []PushMessage{{Opcode: AUTHENTICATE, Timestamp: 1000, Latency: -1}}, 0, nil},
+		{"clustermap change notification", encode(MAGIC_SERVER_REQUEST, Opcode(CLUSTERMAP_CHANGE_NOTIFICATION), 8,
+			[]byte{0, 0, 0, 1}, "travel", []byte(config)), nil, []PushMessage{{Opcode: CLUSTERMAP_CHANGE_NOTIFICATION,
+			Timestamp: 1000, Latency: -1, Key: "travel"}}, 1, nil},
+		{"truncated", authenticate[:len(authenticate)-1], nil, nil, 0, nil},
+		{"oversized key length", oversized, nil, nil, 0, map[ParseErrorKind]uint64{PARSE_ERROR_KEY_LENGTH: 1}},
+	}
+	serverFlow := gopacket.NewFlow(layers.EndpointTCPPort, []byte{0x2b, 0xca}, []byte{0xc3, 0x50})
+	for _, test := range tests {
+		stream := NewStream()
+		stream.HandlePacket(serverFlow, test.push, 1000)
+		if test.answer != nil {
+			stream.HandlePacket(serverFlow.Reverse(), test.answer, 1500)
+		}
+		if !reflect.DeepEqual(stream.pushMessages, test.messages) {
+			t.Errorf("%v: expected messages %+v, got %+v", test.name, test.messages, stream.pushMessages)
+		}
+		if len(stream.configRevisions) != test.revisions {
+			t.Errorf("%v: expected %v cluster map revisions, got %v", test.name, test.revisions, len(stream.configRevisions))
+		}
+		if (len(test.parseErrors) > 0 || len(stream.parseErrors) > 0) && !reflect.DeepEqual(stream.parseErrors, test.parseErrors) {
+			t.Errorf("%v: expected parse errors %v, got %v", test.name, test.parseErrors, stream.parseErrors)
+		}
+	}
+}
+
+func TestPushMessagesAreCapped(t *testing.T) {
+	serverFlow := gopacket.NewFlow(layers.EndpointTCPPort, []byte{0x2b, 0xca}, []byte{0xc3, 0x50})
+	stream := NewStream()
+	for opaque := uint32(0); opaque < maxUnansweredOps+5; opaque++ {
+		stream.HandlePacket(serverFlow, encode(MAGIC_SERVER_REQUEST, Opcode(AUTHENTICATE), opaque, nil, "", nil), 1000)
+	}
+	if len(stream.pushMessages) != maxUnansweredOps || len(stream.pendingPushes) != maxUnansweredOps {
+		t.Errorf("expected %v messages, got %v with %v pending", maxUnansweredOps, len(stream.pushMessages),
+			len(stream.pendingPushes))
+	}
+	if stream.droppedPushes != 5 {
+		t.Errorf("expected 5 dropped messages, got %v", stream.droppedPushes)
+	}
+}
@@ -0,0 +1,85 @@
+/*
+* Copyright (c) 2017 Couchbase, Inc.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package main
+
+import (
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/tcpassembly"
+)
+
+const (
+	defaultMaxBufferedPagesTotal         = 100000
+	defaultMaxBufferedPagesPerConnection = 1000
+	defaultFlushTimeoutInMs              = 2000
+)
+
+//streamFactory hands out one reader per direction of a tcp connection, both directions of
+//a connection share the same Stream so that requests and responses can be matched
+type streamFactory struct {
+	agent *Agent
+}
+
+//streamKey is symmetric so that both directions of a connection map to the same Stream
+func streamKey(netFlow, tcpFlow gopacket.Flow) uint64 {
+	return netFlow.FastHash() ^ tcpFlow.FastHash()
+}
+
+func (factory *streamFactory) New(netFlow, tcpFlow gopacket.Flow) tcpassembly.Stream {
+	key := streamKey(netFlow, tcpFlow)
+	stream := factory.agent.streams[key]
+	if stream == nil {
+		stream = NewStream()
+		stream.recorder = factory.agent.recorder
+		stream.logger = factory.agent.logger
+		factory.agent.streams[key] = stream
+	}
+	stream.hosts[tcpFlow] = netFlow.Src().String()
+	return &streamReader{
+		stream:    stream,
+		direction: tcpFlow,
+	}
+}
+
+//streamReader receives the ordered byte stream of one direction from the assembler
+type streamReader struct {
+	stream    *Stream
+	direction gopacket.Flow
+	ended     bool  //the FIN or RST of the direction was delivered
+	endTime   int64 //capture time of the FIN or RST
+}
+
+func (reader *streamReader) Reassembled(reassemblies []tcpassembly.Reassembly) {
+	for _, reassembly := range reassemblies {
+		//Skip is -1 when the capture joined the connection mid-stream, otherwise the number of lost bytes
+		if reassembly.Skip != 0 {
+			reader.stream.HandleGap(reader.direction, reassembly.Skip)
+		}
+		reader.stream.HandlePacket(reader.direction, reassembly.Bytes, reassembly.Seen.UnixNano())
+		if reassembly.End {
+			reader.ended = true
+			reader.endTime = reassembly.Seen.UnixNano()
+		}
+	}
+}
+
+//ReassemblyComplete is also called for connections which went idle or were still open when the
+//capture ended, only a direction which delivered its FIN or RST counts as closed
+func (reader *streamReader) ReassemblyComplete() {
+	if reader.ended {
+		reader.stream.HandleEnd(reader.direction, reader.endTime)
+	}
+}
@@ -0,0 +1,290 @@
+/*
+* Copyright (c) 2017 Couchbase, Inc.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package main
+
+import (
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"net"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func concat(frames ...[]byte) []byte {
+	var data []byte
+	for _, frame := range frames {
+		data = append(data, frame...)
+	}
+	return data
+}
+
+type endpoint struct {
+	ip   net.IP
+	port layers.TCPPort
+	seq  uint32
+}
+
+//frame builds an ethernet frame from the endpoint to peer and advances the sequence number
+func (from *endpoint) frame(t *testing.T, to *endpoint, tcp *layers.TCP, payload []byte) []byte {
+	ip := &layers.IPv4{
+		Version:  4,
+		TTL:      64,
+		Protocol: layers.IPProtocolTCP,
+		SrcIP:    from.ip,
+		DstIP:    to.ip,
+	}
+	tcp.SrcPort = from.port
+	tcp.DstPort = to.port
+	tcp.Seq = from.seq
+	tcp.Window = 65535
+	if tcp.ACK {
+		tcp.Ack = to.seq
+	}
+	tcp.SetNetworkLayerForChecksum(ip)
+	ethernet := &layers.Ethernet{
+		SrcMAC:       net.HardwareAddr{0, 0, 0, 0, 0, 1},
+		DstMAC:       net.HardwareAddr{0, 0, 0, 0, 0, 2},
+		EthernetType: layers.EthernetTypeIPv4,
+	}
+	buffer := gopacket.NewSerializeBuffer()
+	options := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+	if err := gopacket.SerializeLayers(buffer, options, ethernet, ip, tcp, gopacket.Payload(payload)); err != nil {
+		t.Fatalf("Unable to build frame: %v", err)
+	}
+	from.seq += uint32(len(payload))
+	if tcp.SYN {
+		from.seq++
+	}
+	return buffer.Bytes()
+}
+
+//tcpSegment is sent at offset bytes into the data of its direction, flush hands the data
+//buffered so far to the streams like the flush timeout of a capture does
+type tcpSegment struct {
+	fromClient bool
+	offset     uint32
+	payload    []byte
+	fin        bool
+	rst        bool
+	flush      bool
+}
+
+//assemble feeds the segments of a connection between a client and a server through the
+//assembler of an agent, one millisecond apart, and returns the stream of the connection
+func assemble(t *testing.T, handshake bool, segments []tcpSegment) *Stream {
+	agent := &Agent{config: &Config{}, streams: make(map[uint64]*Stream)}
+	agent.assembler = agent.newAssembler()
+	client := &endpoint{ip: net.IP{10, 0, 0, 1}, port: 50000, seq: 1000}
+	server := &endpoint{ip: net.IP{10, 0, 0, 2}, port: 11210, seq: 5000}
+	start := time.Unix(1500000000, 0)
+	inject := func(from *endpoint, to *endpoint, tcp *layers.TCP, payload []byte, timestamp time.Time) {
+		packet := gopacket.NewPacket(from.frame(t, to, tcp, payload), layers.LayerTypeEthernet, gopacket.Default)
+		packet.Metadata().Timestamp = timestamp
+		agent.handlePacket(packet)
+	}
+	if handshake {
+		inject(client, server, &layers.TCP{SYN: true}, nil, start)
+		inject(server, client, &layers.TCP{SYN: true, ACK: true}, nil, start)
+	}
+	clientSeq, serverSeq := client.seq, server.seq
+	for i, segment := range segments {
+		from, to, seq := server, client, serverSeq
+		if segment.fromClient {
+			from, to, seq = client, server, clientSeq
+		}
+		from.seq = seq + segment.offset
+		timestamp := start.Add(time.Duration(i+1) * time.Millisecond)
+		tcp := &layers.TCP{ACK: true, PSH: len(segment.payload) > 0, FIN: segment.fin, RST: segment.rst}
+		inject(from, to, tcp, segment.payload, timestamp)
+		if segment.flush {
+			agent.assembler.FlushAll()
+		}
+	}
+	agent.assembler.FlushAll()
+
+	if len(agent.streams) != 1 {
+		t.Fatalf("expected a single stream, got %v", len(agent.streams))
+	}
+	for _, stream := range agent.streams {
+		return stream
+	}
+	return nil
+}
+
+func TestReassembly(t *testing.T) {
+	first := encode(0x80, GET, 1, nil, "first", nil)
+	second := encode(0x80, GET, 2, nil, "second", nil)
+	third := encode(0x80, GET, 3, nil, "third", nil)
+	response := func(opaque uint32) []byte {
+		return encode(0x81, GET, opaque, []byte{0, 0, 0, 0}, "", []byte("value"))
+	}
+	tests := []struct {
+		name         string
+		handshake    bool
+		segments     []tcpSegment
+		opaques      []uint32
+		gaps         uint64
+		skippedBytes uint64
+	}{
+		{
+			name:      "in order",
+			handshake: true,
+			segments: []tcpSegment{
+				{fromClient: true, offset: 0, payload: first},
+				{fromClient: false, offset: 0, payload: response(1)},
+			},
+			opaques: []uint32{1},
+		},
+		{
+			name:      "pipelined",
+			handshake: true,
+			segments: []tcpSegment{
+				{fromClient: true, offset: 0, payload: concat(first, second)},
+				{fromClient: false, offset: 0, payload: concat(response(1), response(2))},
+			},
+			opaques: []uint32{1, 2},
+		},
+		{
+			name:      "out of order",
+			handshake: true,
+			segments: []tcpSegment{
+				{fromClient: true, offset: 26, payload: first[26:]},
+				{fromClient: true, offset: 0, payload: first[:26]},
+				{fromClient: false, offset: 0, payload: response(1)},
+			},
+			opaques: []uint32{1},
+		},
+		{
+			name:      "split header",
+			handshake: true,
+			segments: []tcpSegment{
+				{fromClient: true, offset: 0, payload: first[:10]},
+				{fromClient: true, offset: 10, payload: first[10:]},
+				{fromClient: false, offset: 0, payload: response(1)[:23]},
+				{fromClient: false, offset: 23, payload: response(1)[23:]},
+			},
+			opaques: []uint32{1},
+		},
+		{
+			name:      "retransmit",
+			handshake: true,
+			segments: []tcpSegment{
+				{fromClient: true, offset: 0, payload: first},
+				{fromClient: true, offset: 0, payload: first},
+				{fromClient: true, offset: 26, payload: first[26:]},
+				{fromClient: false, offset: 0, payload: response(1)},
+				{fromClient: false, offset: 0, payload: response(1)},
+			},
+			opaques: []uint32{1},
+		},
+		{
+			name:      "hole",
+			handshake: true,
+			segments: []tcpSegment{
+				{fromClient: true, offset: 0, payload: first},
+				{fromClient: true, offset: uint32(len(first)), payload: second},
+				{fromClient: true, offset: uint32(len(first) + len(second)), payload: third},
+				{fromClient: false, offset: 0, payload: response(1)},
+				{fromClient: false, offset: uint32(2 * len(response(1))), payload: response(3)},
+			},
+			opaques:      []uint32{1, 3},
+			gaps:         1,
+			skippedBytes: uint64(len(response(2))),
+		},
+		{
+			name:      "mid-stream join",
+			handshake: false,
+			segments: []tcpSegment{
+				{fromClient: true, offset: 0, payload: first, flush: true},
+				{fromClient: false, offset: 0, payload: response(1)},
+			},
+			opaques: []uint32{1},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stream := assemble(t, test.handshake, test.segments)
+			var opaques []uint32
+			for _, latencyInfo := range stream.latencyInfo {
+				opaques = append(opaques, latencyInfo.Opaque)
+			}
+			sort.Slice(opaques, func(i, j int) bool { return opaques[i] < opaques[j] })
+			if !reflect.DeepEqual(opaques, test.opaques) {
+				t.Errorf("expected ops %v, got %v", test.opaques, opaques)
+			}
+			if stream.gaps != test.gaps || stream.skippedBytes != test.skippedBytes {
+				t.Errorf("expected %v gaps skipping %v bytes, got %v gaps skipping %v bytes",
+					test.gaps, test.skippedBytes, stream.gaps, stream.skippedBytes)
+			}
+		})
+	}
+}
+
+func TestConnectionClose(t *testing.T) {
+	first := encode(MAGIC_REQUEST, GET, 1, nil, "first", nil)
+	second := encode(MAGIC_REQUEST, GET, 2, nil, "second", nil)
+	response := encode(MAGIC_RESPONSE, GET, 1, []byte{0, 0, 0, 0}, "", []byte("value"))
+	requests := uint32(len(first) + len(second))
+	tests := []struct {
+		name           string
+		segments       []tcpSegment
+		ops            int
+		likelyTimeouts uint64
+		closed         bool
+	}{
+		{"half-close", []tcpSegment{
+			{fromClient: true, offset: 0, payload: first},
+			{fromClient: true, offset: uint32(len(first)), fin: true},
+			{fromClient: false, offset: 0, payload: response},
+			{fromClient: false, offset: uint32(len(response)), fin: true},
+		}, 1, 0, true},
+		{"closed with outstanding request", []tcpSegment{
+			{fromClient: true, offset: 0, payload: first},
+			{fromClient: true, offset: uint32(len(first)), fin: true},
+			{fromClient: false, offset: 0, fin: true},
+		}, 0, 1, true},
+		{"one side closed", []tcpSegment{
+			{fromClient: true, offset: 0, payload: first},
+			{fromClient: true, offset: uint32(len(first)), fin: true},
+		}, 0, 0, false},
+		{"reset with outstanding requests", []tcpSegment{
+			{fromClient: true, offset: 0, payload: concat(first, second)},
+			{fromClient: false, offset: 0, payload: response},
+			{fromClient: true, offset: requests, rst: true},
+		}, 1, 1, true},
+		{"open at capture end", []tcpSegment{
+			{fromClient: true, offset: 0, payload: concat(first, second)},
+		}, 0, 0, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stream := assemble(t, true, test.segments)
+			if len(stream.latencyInfo) != test.ops {
+				t.Errorf("expected %v ops, got %v", test.ops, len(stream.latencyInfo))
+			}
+			if stream.likelyTimeouts != test.likelyTimeouts || stream.unanswered != test.likelyTimeouts {
+				t.Errorf("expected %v likely timeouts, got %v of %v unanswered", test.likelyTimeouts, stream.likelyTimeouts,
+					stream.unanswered)
+			}
+			if stream.closed != test.closed {
+				t.Errorf("expected closed to be %v", test.closed)
+			}
+		})
+	}
+}
@@ -0,0 +1,339 @@
+/*
+* Copyright (c) 2017 Couchbase, Inc.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package main
+
+import (
+	"../../logger"
+	pb "../../rpc"
+	"fmt"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/pcapgo"
+	"golang.org/x/net/context"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	defaultRecorderFileSizeInMB   = 64
+	defaultRecorderFiles          = 8
+	defaultRecorderPreservedFiles = 16
+	defaultSlowOpThresholdInMs    = 500
+	defaultPreserveWindowInMs     = 5000
+	maxDownloadChunk              = 1024 * 1024 //stays well below the default grpc message size limit
+)
+
+//recordingFile is one pcapng file of the ring
+type recordingFile struct {
+	name      string
+	size      int64
+	first     int64 //capture time of the first and last packet in nanos
+	last      int64
+	preserved bool //kept out of the ring because a slow op happened around the time it covers
+}
+
+//countingWriter tracks the size of the file being written without asking the file system
+type countingWriter struct {
+	writer io.Writer
+	count  *int64
+}
+
+func (w *countingWriter) Write(data []byte) (int, error) {
+	n, err := w.writer.Write(data)
+	*w.count += int64(n)
+	return n, err
+}
+
+//Recorder writes the captured packets to a ring of rotating pcapng files. Files overlapping the
+//window around a slow op are preserved so the packets of a latency spike can be opened in Wireshark.
+type Recorder struct {
+	mutex         *sync.Mutex
+	config        *RecorderConfig
+	interfaces    []pcapgo.NgInterface //one per capture, in the order of agent.captures
+	logger        *logger.Logger
+	recordings    []*recordingFile //oldest first, the last one is written while file is open
+	file          *os.File
+	writer        *pcapgo.NgWriter
+	sequence      int
+	preserveUntil int64 //packets captured before this time in nanos are preserved
+}
+
+//recorderInterfaces describes every capture as a pcapng interface, each with the link type of its
+//sniffer so captures of different kinds of devices can be recorded to the same file
+func recorderInterfaces(captures []*capture) []pcapgo.NgInterface {
+	var interfaces []pcapgo.NgInterface
+	for _, capture := range captures {
+		intf := pcapgo.DefaultNgInterface
+		intf.Name = capture.device
+		intf.Filter = capture.filter
+		intf.LinkType = capture.sniffer.LinkType()
+		interfaces = append(interfaces, intf)
+	}
+	return interfaces
+}
+
+func NewRecorder(config *RecorderConfig, interfaces []pcapgo.NgInterface, logger *logger.Logger) (*Recorder, error) {
+	if config.FileSizeInMB == 0 {
+		config.FileSizeInMB = defaultRecorderFileSizeInMB
+	}
+	if config.Files == 0 {
+		config.Files = defaultRecorderFiles
+	}
+	if config.PreservedFiles == 0 {
+		config.PreservedFiles = defaultRecorderPreservedFiles
+	}
+	if config.SlowOpThresholdInMs == 0 {
+		config.SlowOpThresholdInMs = defaultSlowOpThresholdInMs
+	}
+	if config.WindowInMs == 0 {
+		config.WindowInMs = defaultPreserveWindowInMs
+	}
+	if err := os.MkdirAll(config.Directory, 0755); err != nil {
+		return nil, err
+	}
+	return &Recorder{
+		mutex:      &sync.Mutex{},
+		config:     config,
+		interfaces: interfaces,
+		logger:     logger,
+	}, nil
+}
+
+func (recorder *Recorder) current() *recordingFile {
+	if recorder.file == nil {
+		return nil
+	}
+	return recorder.recordings[len(recorder.recordings)-1]
+}
+
+//WritePacket adds a packet to the current file, starting a new one when it is full. The interface
+//index of the packet is the index of the capture which sniffed it.
+func (recorder *Recorder) WritePacket(packet gopacket.Packet) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	captureInfo := packet.Metadata().CaptureInfo
+	current := recorder.current()
+	if current == nil || current.size >= int64(recorder.config.FileSizeInMB)*1024*1024 {
+		var err error
+		if current, err = recorder.rotate(captureInfo.Timestamp); err != nil {
+			recorder.logger.Error("Error while starting a new recording: %v", err)
+			return
+		}
+	}
+	if err := recorder.writer.WritePacket(captureInfo, packet.Data()); err != nil {
+		recorder.logger.Error("Error while recording a packet: %v", err)
+		return
+	}
+	timestamp := captureInfo.Timestamp.UnixNano()
+	if current.first == 0 {
+		current.first = timestamp
+	}
+	current.last = timestamp
+	if timestamp <= recorder.preserveUntil {
+		current.preserved = true
+	}
+}
+
+//rotate closes the current file, opens the next one and drops the oldest files over the limits
+func (recorder *Recorder) rotate(timestamp time.Time) (*recordingFile, error) {
+	recorder.closeFile()
+
+	recorder.sequence++
+	current := &recordingFile{
+		name: fmt.Sprintf("tricorder-%s-%06d.pcapng", timestamp.UTC().Format("20060102T150405"), recorder.sequence),
+	}
+	file, err := os.Create(filepath.Join(recorder.config.Directory, current.name))
+	if err != nil {
+		return nil, err
+	}
+	writer, err := pcapgo.NewNgWriterInterface(&countingWriter{writer: file, count: &current.size},
+		recorder.interfaces[0], pcapgo.DefaultNgWriterOptions)
+	for _, intf := range recorder.interfaces[1:] {
+		if err != nil {
+			break
+		}
+		_, err = writer.AddInterface(intf)
+	}
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	recorder.file = file
+	recorder.writer = writer
+	recorder.recordings = append(recorder.recordings, current)
+	recorder.dropOldest()
+	return current, nil
+}
+
+//dropOldest deletes the oldest files once there are more than configured, files still in the
+//ring and preserved files are limited separately
+func (recorder *Recorder) dropOldest() {
+	var ring, preserved int
+	for _, recording := range recorder.recordings {
+		if recording.preserved {
+			preserved++
+		} else {
+			ring++
+		}
+	}
+	var kept []*recordingFile
+	for _, recording := range recorder.recordings {
+		if recording == recorder.current() {
+			kept = append(kept, recording)
+		} else if !recording.preserved && ring > recorder.config.Files {
+			ring--
+			recorder.remove(recording)
+		} else if recording.preserved && preserved > recorder.config.PreservedFiles {
+			preserved--
+			recorder.logger.Info("Dropping preserved recording %v, raise preservedfiles to keep more", recording.name)
+			recorder.remove(recording)
+		} else {
+			kept = append(kept, recording)
+		}
+	}
+	recorder.recordings = kept
+}
+
+func (recorder *Recorder) remove(recording *recordingFile) {
+	if err := os.Remove(filepath.Join(recorder.config.Directory, recording.name)); err != nil {
+		recorder.logger.Error("Error while removing recording %v: %v", recording.name, err)
+	}
+}
+
+func (recorder *Recorder) closeFile() {
+	if recorder.file == nil {
+		return
+	}
+	if err := recorder.writer.Flush(); err != nil {
+		recorder.logger.Error("Error while flushing recording: %v", err)
+	}
+	recorder.file.Close()
+	recorder.file = nil
+	recorder.writer = nil
+}
+
+//Close finishes the current file, the next packet starts a new one
+func (recorder *Recorder) Close() {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	recorder.closeFile()
+}
+
+//HandleLatency preserves the files around an op which took longer than the slow op threshold,
+//from the window before the request was sent to the window after the response was seen
+func (recorder *Recorder) HandleLatency(requestTimeInNanos, responseTimeInNanos int64) {
+	threshold := int64(recorder.config.SlowOpThresholdInMs) * int64(time.Millisecond)
+	if responseTimeInNanos-requestTimeInNanos < threshold {
+		return
+	}
+	window := int64(recorder.config.WindowInMs) * int64(time.Millisecond)
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	for _, recording := range recorder.recordings {
+		if recording.last >= requestTimeInNanos-window {
+			recording.preserved = true
+		}
+	}
+	if responseTimeInNanos+window > recorder.preserveUntil {
+		recorder.preserveUntil = responseTimeInNanos + window
+	}
+}
+
+func (recorder *Recorder) List() []*pb.ListRecordingsResponse_Recording {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	var recordings []*pb.ListRecordingsResponse_Recording
+	for _, recording := range recorder.recordings {
+		recordings = append(recordings, &pb.ListRecordingsResponse_Recording{
+			Name:               recording.name,
+			Size:               uint64(recording.size),
+			FirstPacketInNanos: recording.first,
+			LastPacketInNanos:  recording.last,
+			Preserved:          recording.preserved,
+			Active:             recording == recorder.current(),
+		})
+	}
+	return recordings
+}
+
+//Read returns up to length bytes of a recording starting at offset, eof is set with the last chunk
+//of a file which was rotated or closed. The current file keeps growing and never reports eof.
+func (recorder *Recorder) Read(name string, offset int64, length int) (data []byte, eof bool, err error) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	var found *recordingFile
+	for _, recording := range recorder.recordings {
+		if recording.name == name {
+			found = recording
+		}
+	}
+	if found == nil {
+		//only files of the ring can be read, whatever else is in the directory stays private
+		return nil, false, fmt.Errorf("Unknown recording %v", name)
+	}
+	if found == recorder.current() {
+		if err := recorder.writer.Flush(); err != nil {
+			return nil, false, err
+		}
+	}
+	if length <= 0 || length > maxDownloadChunk {
+		length = maxDownloadChunk
+	}
+
+	file, err := os.Open(filepath.Join(recorder.config.Directory, name))
+	if err != nil {
+		return nil, false, err
+	}
+	defer file.Close()
+	data = make([]byte, length)
+	n, err := file.ReadAt(data, offset)
+	if err != nil && err != io.EOF {
+		return nil, false, err
+	}
+	return data[:n], found != recorder.current() && offset+int64(n) >= found.size, nil
+}
+
+func (agent *Agent) ListRecordings(context.Context, *pb.ListRecordingsRequest) (*pb.ListRecordingsResponse, error) {
+	if agent.recorder == nil {
+		return &pb.ListRecordingsResponse{Status: "recorder disabled"}, nil
+	}
+	return &pb.ListRecordingsResponse{
+		Status:     "success",
+		Recordings: agent.recorder.List(),
+	}, nil
+}
+
+func (agent *Agent) DownloadRecording(ctx context.Context, request *pb.DownloadRecordingRequest) (*pb.DownloadRecordingResponse, error) {
+	if agent.recorder == nil {
+		return &pb.DownloadRecordingResponse{Status: "recorder disabled"}, nil
+	}
+	data, eof, err := agent.recorder.Read(request.Name, int64(request.Offset), int(request.Length))
+	if err != nil {
+		return nil, err
+	}
+	return &pb.DownloadRecordingResponse{
+		Status: "success",
+		Data:   data,
+		Eof:    eof,
+	}, nil
+}
\ No newline at end of file
@@ -0,0 +1,157 @@
+/*
+* Copyright (c) 2017 Couchbase, Inc.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package main
+
+import (
+	"../../logger"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+//recordPacket writes a packet of size bytes sniffed on the interface with the given index
+func recordPacket(recorder *Recorder, index int, linkType layers.LinkType, size int) {
+	data := make([]byte, size)
+	packet := gopacket.NewPacket(data, linkType, gopacket.Default)
+	packet.Metadata().CaptureInfo = gopacket.CaptureInfo{
+		Timestamp:      time.Unix(1500000000, 0),
+		CaptureLength:  len(data),
+		Length:         len(data),
+		InterfaceIndex: index,
+	}
+	recorder.WritePacket(packet)
+}
+
+func TestRecorderWritesEveryCaptureAsItsOwnInterface(t *testing.T) {
+	directory, err := ioutil.TempDir("", "recorder")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	ethernet := pcapgo.DefaultNgInterface
+	ethernet.Name = "eth0"
+	ethernet.LinkType = layers.LinkTypeEthernet
+	loopback := pcapgo.DefaultNgInterface
+	loopback.Name = "any"
+	loopback.LinkType = layers.LinkTypeLinuxSLL
+	recorder, err := NewRecorder(&RecorderConfig{Directory: directory},
+		[]pcapgo.NgInterface{ethernet, loopback}, &logger.Logger{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for index, linkType := range []layers.LinkType{layers.LinkTypeEthernet, layers.LinkTypeLinuxSLL} {
+		recordPacket(recorder, index, linkType, 64)
+	}
+	recorder.Close()
+
+	recordings := recorder.List()
+	if len(recordings) != 1 {
+		t.Fatalf("expected one recording, got %v", recordings)
+	}
+	file, err := os.Open(filepath.Join(directory, recordings[0].Name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	reader, err := pcapgo.NewNgReader(file, pcapgo.NgReaderOptions{WantMixedLinkType: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for index, linkType := range []layers.LinkType{layers.LinkTypeEthernet, layers.LinkTypeLinuxSLL} {
+		_, captureInfo, err := reader.ReadPacketData()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if captureInfo.InterfaceIndex != index {
+			t.Fatalf("packet %v was written to interface %v", index, captureInfo.InterfaceIndex)
+		}
+		intf, err := reader.Interface(index)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if intf.LinkType != linkType {
+			t.Fatalf("interface %v has link type %v instead of %v", index, intf.LinkType, linkType)
+		}
+	}
+}
+
+func TestReadRecording(t *testing.T) {
+	directory, err := ioutil.TempDir("", "recorder")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	recorder, err := NewRecorder(&RecorderConfig{Directory: directory, FileSizeInMB: 1},
+		[]pcapgo.NgInterface{pcapgo.DefaultNgInterface}, &logger.Logger{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	//the packet after the first megabyte rotates to the second file
+	for i := 0; i <= 16; i++ {
+		recordPacket(recorder, 0, layers.LinkTypeEthernet, 64*1024)
+	}
+	recordings := recorder.List()
+	if len(recordings) != 2 || recordings[0].Active || !recordings[1].Active {
+		t.Fatalf("expected a rotated and an active recording, got %v", recordings)
+	}
+	//reading flushes the current file, the size of the listing is up to date afterwards
+	if _, _, err := recorder.Read(recordings[1].Name, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	rotated, current := recordings[0], recorder.List()[1]
+	rotatedSize := int64(rotated.Size)
+	tests := []struct {
+		name   string
+		file   string
+		offset int64
+		length int
+		size   int
+		eof    bool
+	}{
+		{"first chunk", rotated.Name, 0, 1024, 1024, false},
+		{"up to the end", rotated.Name, 1024, int(rotatedSize) - 1024, int(rotatedSize) - 1024, true},
+		{"past the end", rotated.Name, rotatedSize - 10, 1024, 10, true},
+		{"beyond the end", rotated.Name, rotatedSize + 10, 1024, 0, true},
+		{"current file", current.Name, 0, maxDownloadChunk, int(current.Size), false},
+		{"past the end of the current file", current.Name, int64(current.Size) - 10, 1024, 10, false},
+	}
+	for _, test := range tests {
+		data, eof, err := recorder.Read(test.file, test.offset, test.length)
+		if err != nil {
+			t.Fatalf("%v: %v", test.name, err)
+		}
+		if len(data) != test.size || eof != test.eof {
+			t.Errorf("%v: expected %v bytes and eof %v, got %v bytes and eof %v", test.name, test.size, test.eof,
+				len(data), eof)
+		}
+	}
+
+	recorder.Close()
+	if _, eof, err := recorder.Read(current.Name, 0, maxDownloadChunk); err != nil || !eof {
+		t.Errorf("expected eof once the recording was closed, got %v: %v", eof, err)
+	}
+	if _, _, err := recorder.Read("../recording.pcapng", 0, 1024); err == nil {
+		t.Errorf("expected an error for a file which is not a recording")
+	}
+}
\ No newline at end of file
@@ -0,0 +1,95 @@
+/*
+* Copyright (c) 2017 Couchbase, Inc.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"strings"
+)
+
+//handleSessionRequest attributes a request to the bucket and user of the connection at the time
+//it was sent and picks up the user name of an authentication attempt
+func (stream *Stream) handleSessionRequest(request *Command) {
+	request.bucket = stream.bucket
+	request.user = stream.user
+	request.sdk = stream.sdk
+	if stream.features[FEATURE_COLLECTIONS] && request.opcode.isDocumentOp() {
+		request.decodeCollectionId()
+	}
+
+	if request.opcode == SASL_AUTH {
+		stream.pendingUser = saslUsername(string(request.key), request.value)
+		request.value = nil //the value holds the password, never keep it around
+	}
+	if request.opcode == HELLO {
+		stream.userAgent, stream.clientConnectionId = decodeUserAgent(request.key)
+		stream.sdk = sdkName(stream.userAgent)
+	}
+}
+
+//handleSessionResponse updates the session state of the connection once the server accepted
+//a bucket selection, an authentication or a feature negotiation, and picks up cluster maps
+func (stream *Stream) handleSessionResponse(request, response *Command) {
+	if response.status == NOT_MY_VBUCKET {
+		stream.handleClusterConfig(request.bucket, response, NOT_MY_VBUCKET.String())
+		return
+	}
+	if response.status != SUCCESS {
+		return
+	}
+	switch request.opcode {
+	case SELECT_BUCKET:
+		stream.bucket = string(request.key)
+	case SASL_AUTH, SASL_STEP:
+		stream.user = stream.pendingUser
+		stream.pendingUser = ""
+	case HELLO:
+		stream.features = decodeFeatures(response.value)
+	case COLLECTIONS_GET_MANIFEST:
+		if names, err := decodeCollectionsManifest(response.value); err == nil {
+			stream.manifests[request.bucket] = names
+		}
+	case GET_CLUSTER_CONFIG:
+		stream.handleClusterConfig(request.bucket, response, GET_CLUSTER_CONFIG.String())
+	}
+}
+
+//saslUsername extracts the user name from the initial client message of a SASL exchange
+func saslUsername(mechanism string, value []byte) string {
+	if mechanism == "PLAIN" {
+		//[authzid] NUL authcid NUL passwd
+		fields := bytes.Split(value, []byte{0})
+		if len(fields) == 3 {
+			return string(fields[1])
+		}
+		return ""
+	}
+
+	if strings.HasPrefix(mechanism, "SCRAM-") {
+		//gs2-header followed by n=user,r=nonce where ',' and '=' in the user are escaped
+		fields := strings.Split(string(value), ",")
+		if len(fields) < 3 {
+			return ""
+		}
+		for _, field := range fields[2:] {
+			if strings.HasPrefix(field, "n=") {
+				return strings.NewReplacer("=2C", ",", "=3D", "=").Replace(field[2:])
+			}
+		}
+	}
+	return ""
+}
\ No newline at end of file
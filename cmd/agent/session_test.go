@@ -0,0 +1,82 @@
+/*
+* Copyright (c) 2017 Couchbase, Inc.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/binary"
+	"io"
+	"testing"
+)
+
+func TestSaslUsername(t *testing.T) {
+	tests := []struct {
+		name      string
+		mechanism string
+		value     []byte
+		user      string
+	}{
+		{"plain", "PLAIN", []byte("\x00alice\x00secret"), "alice"},
+		{"plain with authzid", "PLAIN", []byte("admin\x00alice\x00secret"), "alice"},
+		{"plain truncated", "PLAIN", []byte("\x00alice"), ""},
+		{"plain extra field", "PLAIN", []byte("\x00alice\x00secret\x00more"), ""},
+		{"plain empty", "PLAIN", nil, ""},
+		{"scram", "SCRAM-SHA512", []byte("n,,n=alice,r=fyko+d2lbbFgONRv9qkxdawL"), "alice"},
+		{"scram escaped", "SCRAM-SHA256", []byte("n,,n=a=2Cb=3Dc,r=nonce"), "a,b=c"},
+		{"scram truncated", "SCRAM-SHA1", []byte("n,,"), ""},
+		{"scram without user", "SCRAM-SHA1", []byte("n,,r=nonce"), ""},
+		{"unknown mechanism", "CRAM-MD5", []byte("\x00alice\x00secret"), ""},
+	}
+	for _, test := range tests {
+		if user := saslUsername(test.mechanism, test.value); user != test.user {
+			t.Errorf("%v: expected user %q, got %q", test.name, test.user, user)
+		}
+	}
+}
+
+func TestSaslAuthFrames(t *testing.T) {
+	auth := encode(MAGIC_REQUEST, SASL_AUTH, 1, nil, "PLAIN", []byte("\x00alice\x00secret"))
+	oversized := append([]byte(nil), auth...)
+	binary.BigEndian.PutUint16(oversized[2:4], 64) //key runs past the body
+	tests := []struct {
+		name  string
+		frame []byte
+		err   error
+		user  string
+	}{
+		{"valid", auth, nil, "alice"},
+		{"truncated value", auth[:len(auth)-4], io.EOF, ""},
+		{"truncated header", auth[:10], io.EOF, ""},
+		{"oversized key length", oversized, &ParseError{Kind: PARSE_ERROR_BODY_LENGTH}, ""},
+	}
+	for _, test := range tests {
+		stream := NewStream()
+		request, err := decode(test.frame)
+		if !sameError(err, test.err) {
+			t.Errorf("%v: expected error %v, got %v", test.name, test.err, err)
+			continue
+		}
+		if err == nil {
+			stream.handleSessionRequest(request)
+			if request.value != nil {
+				t.Errorf("%v: the password was kept", test.name)
+			}
+		}
+		if stream.pendingUser != test.user {
+			t.Errorf("%v: expected user %q, got %q", test.name, test.user, stream.pendingUser)
+		}
+	}
+}
\ No newline at end of file
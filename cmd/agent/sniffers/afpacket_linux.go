@@ -1,4 +1,6 @@
+//go:build linux
 // +build linux
+
 /*
 * Copyright (c) 2017 Couchbase, Inc.
 *
@@ -18,47 +20,114 @@
 package sniffers
 
 import (
+	"fmt"
 	"github.com/google/gopacket"
 	"github.com/google/gopacket/afpacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcap"
+	"golang.org/x/net/bpf"
+	"os"
 	"time"
 )
 
+const defaultAfpacketBufferSizeInMB = 32
+
+func init() {
+	Register(AF_PACKET, func() Sniffer { return &AfpacketHandle{} })
+}
+
 type AfpacketHandle struct {
 	TPacket *afpacket.TPacket
+	snaplen int
 }
 
-func NewAfpacketHandle(device string, snaplen int, block_size int, num_blocks int,
-	timeout time.Duration) (*AfpacketHandle, error) {
+func afpacketComputeSize(targetSizeMb int, snaplen int, pageSize int) (
+	frameSize int, blockSize int, numBlocks int, err error) {
 
-	h := &AfpacketHandle{}
-	var err error
+	if snaplen < pageSize {
+		frameSize = pageSize / (pageSize / snaplen)
+	} else {
+		frameSize = (snaplen/pageSize + 1) * pageSize
+	}
+	// 128 is the default from the gopacket library so just use that
+	blockSize = frameSize * 128
+	numBlocks = (targetSizeMb * 1024 * 1024) / blockSize
 
-	if device == "any" {
+	if numBlocks == 0 {
+		return 0, 0, 0, fmt.Errorf("Buffer size too small")
+	}
+	return frameSize, blockSize, numBlocks, nil
+}
+
+func (h *AfpacketHandle) Open(options Options) (err error) {
+	bufferSizeInMB := options.BufferSizeInMB
+	if bufferSizeInMB == 0 {
+		bufferSizeInMB = defaultAfpacketBufferSizeInMB
+	}
+	frameSize, blockSize, numBlocks, err := afpacketComputeSize(bufferSizeInMB, options.Snaplen, os.Getpagesize())
+	if err != nil {
+		return err
+	}
+	timeout := 30 * time.Millisecond
+	h.snaplen = options.Snaplen
+
+	if options.Device == "any" {
 		h.TPacket, err = afpacket.NewTPacket(
-			afpacket.OptFrameSize(snaplen),
-			afpacket.OptBlockSize(block_size),
-			afpacket.OptNumBlocks(num_blocks),
+			afpacket.OptFrameSize(frameSize),
+			afpacket.OptBlockSize(blockSize),
+			afpacket.OptNumBlocks(numBlocks),
 			afpacket.OptPollTimeout(timeout))
 	} else {
 		h.TPacket, err = afpacket.NewTPacket(
-			afpacket.OptInterface(device),
-			afpacket.OptFrameSize(snaplen),
-			afpacket.OptBlockSize(block_size),
-			afpacket.OptNumBlocks(num_blocks),
+			afpacket.OptInterface(options.Device),
+			afpacket.OptFrameSize(frameSize),
+			afpacket.OptBlockSize(blockSize),
+			afpacket.OptNumBlocks(numBlocks),
 			afpacket.OptPollTimeout(timeout))
 	}
-
-	return h, err
+	return err
 }
 
+//SetBPFFilter compiles the expression with libpcap and attaches the program to the socket,
+//the ring has no filter support of its own
 func (h *AfpacketHandle) SetBPFFilter(expr string) (_ error) {
-	return h.TPacket.SetBPFFilter(expr)
+	instructions, err := pcap.CompileBPFFilter(h.LinkType(), h.snaplen, expr)
+	if err != nil {
+		return err
+	}
+	filter := make([]bpf.RawInstruction, len(instructions))
+	for i, instruction := range instructions {
+		filter[i] = bpf.RawInstruction{
+			Op: instruction.Code,
+			Jt: instruction.Jt,
+			Jf: instruction.Jf,
+			K:  instruction.K,
+		}
+	}
+	return h.TPacket.SetBPF(filter)
 }
 
-func (h *AfpacketHandle) Close() {
-	h.TPacket.Close()
+func (h *AfpacketHandle) GetPacketSource() *gopacket.PacketSource {
+	return gopacket.NewPacketSource(h.TPacket, layers.LinkTypeEthernet)
 }
 
-func (h *AfpacketHandle) GetPacketSource() *gopacket.PacketSource {
-	return gopacket.NewPacketSource(TPacket, layers.LinkTypeEthernet)
+func (h *AfpacketHandle) LinkType() layers.LinkType {
+	return layers.LinkTypeEthernet
 }
+
+//Stats reads the socket statistics, only one of the TPACKET_V1/V2 and V3 counters is filled in
+//depending on the version of the ring
+func (h *AfpacketHandle) Stats() (Stats, error) {
+	stats, statsV3, err := h.TPacket.SocketStats()
+	if err != nil {
+		return Stats{}, err
+	}
+	return Stats{
+		Received: uint64(stats.Packets() + statsV3.Packets()),
+		Dropped:  uint64(stats.Drops() + statsV3.Drops()),
+	}, nil
+}
+
+func (h *AfpacketHandle) Close() {
+	h.TPacket.Close()
+}
\ No newline at end of file
@@ -1,4 +1,6 @@
+//go:build !linux
 // +build !linux
+
 /*
 * Copyright (c) 2017 Couchbase, Inc.
 *
@@ -20,24 +22,35 @@ package sniffers
 import (
 	"fmt"
 	"github.com/google/gopacket"
-	"time"
+	"github.com/google/gopacket/layers"
 )
 
+func init() {
+	Register(AF_PACKET, func() Sniffer { return &AfpacketHandle{} })
+}
+
 type AfpacketHandle struct {
 }
 
-func NewAfpacketHandle(device string, snaplen int, blockSize int, numBlocks int,
-	timeout time.Duration) (*AfpacketHandle, error) {
-	return nil, fmt.Errorf("Afpacket sniffing is only available on Linux")
+func (h *AfpacketHandle) Open(options Options) (_ error) {
+	return fmt.Errorf("Afpacket sniffing is only available on Linux")
 }
 
 func (h *AfpacketHandle) SetBPFFilter(expr string) (_ error) {
-	return fmt.Errorf("Afpacket  sniffing is only available on Linux")
+	return fmt.Errorf("Afpacket sniffing is only available on Linux")
 }
 
 func (h *AfpacketHandle) GetPacketSource() *gopacket.PacketSource {
 	return nil
 }
 
-func (h *AfpacketHandle) Close() {
+func (h *AfpacketHandle) LinkType() layers.LinkType {
+	return layers.LinkTypeEthernet
 }
+
+func (h *AfpacketHandle) Stats() (Stats, error) {
+	return Stats{}, fmt.Errorf("Afpacket sniffing is only available on Linux")
+}
+
+func (h *AfpacketHandle) Close() {
+}
\ No newline at end of file
@@ -0,0 +1,108 @@
+/*
+* Copyright (c) 2017 Couchbase, Inc.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package sniffers
+
+import (
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"io"
+	"sync"
+)
+
+func init() {
+	Register(MEMORY, func() Sniffer { return NewMemoryHandle() })
+}
+
+type memoryPacket struct {
+	data        []byte
+	captureInfo gopacket.CaptureInfo
+}
+
+//MemoryHandle hands out the packets injected by the caller, reading blocks until a packet is
+//injected and returns io.EOF once the handle is closed and all packets were read
+type MemoryHandle struct {
+	mutex    *sync.Mutex
+	cond     *sync.Cond
+	packets  []memoryPacket
+	received uint64
+	closed   bool
+}
+
+func NewMemoryHandle() *MemoryHandle {
+	mutex := &sync.Mutex{}
+	return &MemoryHandle{
+		mutex: mutex,
+		cond:  sync.NewCond(mutex),
+	}
+}
+
+func (h *MemoryHandle) Open(options Options) (_ error) {
+	return nil
+}
+
+//SetBPFFilter does nothing, the caller injects the packets it wants to be seen
+func (h *MemoryHandle) SetBPFFilter(expr string) (_ error) {
+	return nil
+}
+
+//Inject queues an ethernet frame, captureInfo.Timestamp is the capture time seen by the agent
+func (h *MemoryHandle) Inject(data []byte, captureInfo gopacket.CaptureInfo) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if captureInfo.CaptureLength == 0 {
+		captureInfo.CaptureLength = len(data)
+		captureInfo.Length = len(data)
+	}
+	h.packets = append(h.packets, memoryPacket{data: data, captureInfo: captureInfo})
+	h.cond.Signal()
+}
+
+func (h *MemoryHandle) ReadPacketData() ([]byte, gopacket.CaptureInfo, error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	for len(h.packets) == 0 && !h.closed {
+		h.cond.Wait()
+	}
+	if len(h.packets) == 0 {
+		return nil, gopacket.CaptureInfo{}, io.EOF
+	}
+	packet := h.packets[0]
+	h.packets = h.packets[1:]
+	h.received++
+	return packet.data, packet.captureInfo, nil
+}
+
+func (h *MemoryHandle) GetPacketSource() *gopacket.PacketSource {
+	return gopacket.NewPacketSource(h, layers.LinkTypeEthernet)
+}
+
+func (h *MemoryHandle) LinkType() layers.LinkType {
+	return layers.LinkTypeEthernet
+}
+
+func (h *MemoryHandle) Stats() (Stats, error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return Stats{Received: h.received}, nil
+}
+
+func (h *MemoryHandle) Close() {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.closed = true
+	h.cond.Broadcast()
+}
\ No newline at end of file
@@ -0,0 +1,69 @@
+/*
+* Copyright (c) 2017 Couchbase, Inc.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package sniffers
+
+import (
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcap"
+)
+
+func init() {
+	Register(PCAP, func() Sniffer { return &PcapHandle{} })
+}
+
+//PcapHandle sniffs an interface with libpcap, it works on most platforms
+type PcapHandle struct {
+	Handle *pcap.Handle
+}
+
+func (h *PcapHandle) Open(options Options) (err error) {
+	h.Handle, err = pcap.OpenLive(options.Device, int32(options.Snaplen), options.Promisc, pcap.BlockForever)
+	return err
+}
+
+func (h *PcapHandle) SetBPFFilter(expr string) (_ error) {
+	return h.Handle.SetBPFFilter(expr)
+}
+
+func (h *PcapHandle) GetPacketSource() *gopacket.PacketSource {
+	return gopacket.NewPacketSource(h.Handle, h.Handle.LinkType())
+}
+
+func (h *PcapHandle) LinkType() layers.LinkType {
+	return h.Handle.LinkType()
+}
+
+func (h *PcapHandle) Stats() (Stats, error) {
+	return pcapStats(h.Handle)
+}
+
+func (h *PcapHandle) Close() {
+	h.Handle.Close()
+}
+
+func pcapStats(handle *pcap.Handle) (Stats, error) {
+	stats, err := handle.Stats()
+	if err != nil {
+		return Stats{}, err
+	}
+	return Stats{
+		Received:  uint64(stats.PacketsReceived),
+		Dropped:   uint64(stats.PacketsDropped),
+		IfDropped: uint64(stats.PacketsIfDropped),
+	}, nil
+}
\ No newline at end of file
@@ -0,0 +1,69 @@
+/*
+* Copyright (c) 2017 Couchbase, Inc.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package sniffers
+
+import (
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcap"
+	"sync/atomic"
+)
+
+func init() {
+	Register(PCAP_FILE, func() Sniffer { return &PcapFileHandle{} })
+}
+
+//PcapFileHandle reads a pcap or pcapng file, packets keep the timestamps of the original capture
+type PcapFileHandle struct {
+	Handle  *pcap.Handle
+	packets uint64 //updated by the reader goroutine of the packet source, accessed atomically
+}
+
+func (h *PcapFileHandle) Open(options Options) (err error) {
+	h.Handle, err = pcap.OpenOffline(options.Device)
+	return err
+}
+
+func (h *PcapFileHandle) SetBPFFilter(expr string) (_ error) {
+	return h.Handle.SetBPFFilter(expr)
+}
+
+func (h *PcapFileHandle) GetPacketSource() *gopacket.PacketSource {
+	return gopacket.NewPacketSource(h, h.Handle.LinkType())
+}
+
+func (h *PcapFileHandle) ReadPacketData() ([]byte, gopacket.CaptureInfo, error) {
+	data, captureInfo, err := h.Handle.ReadPacketData()
+	if err == nil {
+		atomic.AddUint64(&h.packets, 1)
+	}
+	return data, captureInfo, err
+}
+
+func (h *PcapFileHandle) LinkType() layers.LinkType {
+	return h.Handle.LinkType()
+}
+
+//Stats counts the packets read from the file which passed the filter, libpcap keeps no
+//statistics for files and nothing can be dropped
+func (h *PcapFileHandle) Stats() (Stats, error) {
+	return Stats{Received: atomic.LoadUint64(&h.packets)}, nil
+}
+
+func (h *PcapFileHandle) Close() {
+	h.Handle.Close()
+}
\ No newline at end of file
@@ -1,4 +1,6 @@
-// +build linux,havepfring
+//go:build linux
+// +build linux
+
 /*
 * Copyright (c) 2017 Couchbase, Inc.
 *
@@ -20,44 +22,59 @@ package sniffers
 import (
 	"fmt"
 	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
 	"github.com/google/gopacket/pfring"
 )
 
+func init() {
+	Register(PF_RING, func() Sniffer { return &PfringHandle{} })
+}
+
 type PfringHandle struct {
 	Ring *pfring.Ring
 }
 
-func NewPfringHandle(device string, snaplen int, promisc bool) (*PfringHandle, error) {
-	var h PfringHandle
-	var err error
-
-	if device == "any" {
-		return nil, fmt.Errorf("Pfring sniffing doesn't support 'any' as interface")
+func (h *PfringHandle) Open(options Options) (err error) {
+	if options.Device == "any" {
+		return fmt.Errorf("Pfring sniffing doesn't support 'any' as interface")
 	}
 
 	var flags pfring.Flag
 
-	if promisc {
+	if options.Promisc {
 		flags = pfring.FlagPromisc
 	}
 
-	h.Ring, err = pfring.NewRing(device, uint32(snaplen), flags)
-
-	return &h, err
+	h.Ring, err = pfring.NewRing(options.Device, uint32(options.Snaplen), flags)
+	if err != nil {
+		return err
+	}
+	return h.Ring.Enable()
 }
 
 func (h *PfringHandle) SetBPFFilter(expr string) (_ error) {
 	return h.Ring.SetBPFFilter(expr)
 }
 
-func (h *PfringHandle) Enable() (_ error) {
-	return h.Ring.Enable()
+func (h *PfringHandle) GetPacketSource() *gopacket.PacketSource {
+	return gopacket.NewPacketSource(h.Ring, layers.LinkTypeEthernet)
 }
 
-func (h *PfringHandle) GetPacketSource() *gopacket.PacketSource {
-	return gopacket.NewPacketSource(Ring, layers.LinkTypeEthernet)
+func (h *PfringHandle) LinkType() layers.LinkType {
+	return layers.LinkTypeEthernet
+}
+
+func (h *PfringHandle) Stats() (Stats, error) {
+	stats, err := h.Ring.Stats()
+	if err != nil {
+		return Stats{}, err
+	}
+	return Stats{
+		Received: stats.Received,
+		Dropped:  stats.Dropped,
+	}, nil
 }
 
 func (h *PfringHandle) Close() {
 	h.Ring.Close()
-}
+}
\ No newline at end of file
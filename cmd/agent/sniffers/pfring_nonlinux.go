@@ -1,4 +1,6 @@
+//go:build !linux
 // +build !linux
+
 /*
 * Copyright (c) 2017 Couchbase, Inc.
 *
@@ -20,20 +22,21 @@ package sniffers
 import (
 	"fmt"
 	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
 )
 
-type PfringHandle struct {
+func init() {
+	Register(PF_RING, func() Sniffer { return &PfringHandle{} })
 }
 
-func NewPfringHandle(device string, snaplen int, promisc bool) (*PfringHandle, error) {
-	return nil, fmt.Errorf("PF_RING sniffing is only available on Linux")
+type PfringHandle struct {
 }
 
-func (h *PfringHandle) SetBPFFilter(expr string) (_ error) {
+func (h *PfringHandle) Open(options Options) (_ error) {
 	return fmt.Errorf("PF_RING sniffing is only available on Linux")
 }
 
-func (h *PfringHandle) Enable() (_ error) {
+func (h *PfringHandle) SetBPFFilter(expr string) (_ error) {
 	return fmt.Errorf("PF_RING sniffing is only available on Linux")
 }
 
@@ -41,5 +44,13 @@ func (h *PfringHandle) GetPacketSource() *gopacket.PacketSource {
 	return nil
 }
 
-func (h *PfringHandle) Close() {
+func (h *PfringHandle) LinkType() layers.LinkType {
+	return layers.LinkTypeEthernet
+}
+
+func (h *PfringHandle) Stats() (Stats, error) {
+	return Stats{}, fmt.Errorf("PF_RING sniffing is only available on Linux")
 }
+
+func (h *PfringHandle) Close() {
+}
\ No newline at end of file
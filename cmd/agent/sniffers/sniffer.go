@@ -0,0 +1,83 @@
+/*
+* Copyright (c) 2017 Couchbase, Inc.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package sniffers
+
+import (
+	"fmt"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"sort"
+)
+
+//types of sniffers which can be configured for the agent
+const (
+	PCAP      = "pcap"
+	AF_PACKET = "afpacket"
+	PF_RING   = "pfring"
+	PCAP_FILE = "pcapfile" //device is the path of a pcap or pcapng file
+	MEMORY    = "memory"   //packets are injected by the caller, used by tests
+)
+
+type Options struct {
+	Device         string //network interface, or the file read by the pcapfile sniffer
+	Snaplen        int
+	Promisc        bool
+	BufferSizeInMB int //size of the af_packet ring
+}
+
+//Stats are the packet counters of a sniffer since it was opened, counters a sniffer can not
+//provide stay 0
+type Stats struct {
+	Received  uint64
+	Dropped   uint64 //dropped by the kernel or the ring because the packets were not read in time
+	IfDropped uint64 //dropped by the network interface
+}
+
+//Sniffer is a source of captured packets
+type Sniffer interface {
+	Open(options Options) error
+	SetBPFFilter(filter string) error
+	GetPacketSource() *gopacket.PacketSource
+	LinkType() layers.LinkType
+	Stats() (Stats, error)
+	Close()
+}
+
+var registry = make(map[string]func() Sniffer)
+
+//Register makes a sniffer available under name, every sniffer registers itself in an init function
+func Register(name string, factory func() Sniffer) {
+	registry[name] = factory
+}
+
+//New returns an unopened sniffer of the given type
+func New(name string) (Sniffer, error) {
+	factory, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("Unknown sniffer type %v, supported types are %v", name, Names())
+	}
+	return factory(), nil
+}
+
+func Names() []string {
+	var names []string
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
\ No newline at end of file
@@ -0,0 +1,182 @@
+/*
+* Copyright (c) 2017 Couchbase, Inc.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package main
+
+import "fmt"
+
+type Status uint16
+
+//memcached binary protocol response status codes, see kv_engine include/mcbp/protocol/status.h
+const (
+	SUCCESS                                 Status = 0x00
+	KEY_ENOENT                              Status = 0x01
+	KEY_EEXISTS                             Status = 0x02
+	E2BIG                                   Status = 0x03
+	EINVAL                                  Status = 0x04
+	NOT_STORED                              Status = 0x05
+	DELTA_BADVAL                            Status = 0x06
+	NOT_MY_VBUCKET                          Status = 0x07
+	NO_BUCKET                               Status = 0x08
+	LOCKED                                  Status = 0x09
+	DCP_STREAM_NOT_FOUND                    Status = 0x0a
+	OPAQUE_NO_MATCH                         Status = 0x0b
+	EWOULDTHROTTLE                          Status = 0x0c
+	ECONFIGONLY                             Status = 0x0d
+	NOT_LOCKED                              Status = 0x0e
+	CAS_VALUE_INVALID                       Status = 0x0f
+	AUTH_STALE                              Status = 0x1f
+	AUTH_ERROR                              Status = 0x20
+	AUTH_CONTINUE                           Status = 0x21
+	ERANGE                                  Status = 0x22
+	ROLLBACK                                Status = 0x23
+	EACCESS                                 Status = 0x24
+	NOT_INITIALIZED                         Status = 0x25
+	RATE_LIMITED_NETWORK_INGRESS            Status = 0x30
+	RATE_LIMITED_NETWORK_EGRESS             Status = 0x31
+	RATE_LIMITED_MAX_CONNECTIONS            Status = 0x32
+	RATE_LIMITED_MAX_COMMANDS               Status = 0x33
+	SCOPE_SIZE_LIMIT_EXCEEDED               Status = 0x34
+	BUCKET_SIZE_LIMIT_EXCEEDED              Status = 0x35
+	UNKNOWN_COMMAND                         Status = 0x81
+	ENOMEM                                  Status = 0x82
+	NOT_SUPPORTED                           Status = 0x83
+	EINTERNAL                               Status = 0x84
+	EBUSY                                   Status = 0x85
+	ETMPFAIL                                Status = 0x86
+	XATTR_EINVAL                            Status = 0x87
+	UNKNOWN_COLLECTION                      Status = 0x88
+	NO_COLLECTIONS_MANIFEST                 Status = 0x89
+	CANNOT_APPLY_COLLECTIONS_MANIFEST       Status = 0x8a
+	COLLECTIONS_MANIFEST_IS_AHEAD           Status = 0x8b
+	UNKNOWN_SCOPE                           Status = 0x8c
+	DCP_STREAMID_INVALID                    Status = 0x8d
+	DURABILITY_INVALID_LEVEL                Status = 0xa0
+	DURABILITY_IMPOSSIBLE                   Status = 0xa1
+	SYNC_WRITE_IN_PROGRESS                  Status = 0xa2
+	SYNC_WRITE_AMBIGUOUS                    Status = 0xa3
+	SYNC_WRITE_RECOMMIT_IN_PROGRESS         Status = 0xa4
+	SUBDOC_PATH_ENOENT                      Status = 0xc0
+	SUBDOC_PATH_MISMATCH                    Status = 0xc1
+	SUBDOC_PATH_EINVAL                      Status = 0xc2
+	SUBDOC_PATH_E2BIG                       Status = 0xc3
+	SUBDOC_DOC_E2DEEP                       Status = 0xc4
+	SUBDOC_VALUE_CANTINSERT                 Status = 0xc5
+	SUBDOC_DOC_NOT_JSON                     Status = 0xc6
+	SUBDOC_NUM_ERANGE                       Status = 0xc7
+	SUBDOC_DELTA_EINVAL                     Status = 0xc8
+	SUBDOC_PATH_EEXISTS                     Status = 0xc9
+	SUBDOC_VALUE_ETOODEEP                   Status = 0xca
+	SUBDOC_INVALID_COMBO                    Status = 0xcb
+	SUBDOC_MULTI_PATH_FAILURE               Status = 0xcc
+	SUBDOC_SUCCESS_DELETED                  Status = 0xcd
+	SUBDOC_XATTR_INVALID_FLAG_COMBO         Status = 0xce
+	SUBDOC_XATTR_INVALID_KEY_COMBO          Status = 0xcf
+	SUBDOC_XATTR_UNKNOWN_MACRO              Status = 0xd0
+	SUBDOC_XATTR_UNKNOWN_VATTR              Status = 0xd1
+	SUBDOC_XATTR_CANT_MODIFY_VATTR          Status = 0xd2
+	SUBDOC_MULTI_PATH_FAILURE_DELETED       Status = 0xd3
+	SUBDOC_INVALID_XATTR_ORDER              Status = 0xd4
+	SUBDOC_XATTR_UNKNOWN_VATTR_MACRO        Status = 0xd5
+	SUBDOC_CAN_ONLY_REVIVE_DELETED_DOCUMENT Status = 0xd6
+	SUBDOC_DELETED_DOCUMENT_CANT_HAVE_VALUE Status = 0xd7
+)
+
+var statusNames = map[Status]string{
+	SUCCESS:                                 "SUCCESS",
+	KEY_ENOENT:                              "KEY_ENOENT",
+	KEY_EEXISTS:                             "KEY_EEXISTS",
+	E2BIG:                                   "E2BIG",
+	EINVAL:                                  "EINVAL",
+	NOT_STORED:                              "NOT_STORED",
+	DELTA_BADVAL:                            "DELTA_BADVAL",
+	NOT_MY_VBUCKET:                          "NOT_MY_VBUCKET",
+	NO_BUCKET:                               "NO_BUCKET",
+	LOCKED:                                  "LOCKED",
+	DCP_STREAM_NOT_FOUND:                    "DCP_STREAM_NOT_FOUND",
+	OPAQUE_NO_MATCH:                         "OPAQUE_NO_MATCH",
+	EWOULDTHROTTLE:                          "EWOULDTHROTTLE",
+	ECONFIGONLY:                             "ECONFIGONLY",
+	NOT_LOCKED:                              "NOT_LOCKED",
+	CAS_VALUE_INVALID:                       "CAS_VALUE_INVALID",
+	AUTH_STALE:                              "AUTH_STALE",
+	AUTH_ERROR:                              "AUTH_ERROR",
+	AUTH_CONTINUE:                           "AUTH_CONTINUE",
+	ERANGE:                                  "ERANGE",
+	ROLLBACK:                                "ROLLBACK",
+	EACCESS:                                 "EACCESS",
+	NOT_INITIALIZED:                         "NOT_INITIALIZED",
+	RATE_LIMITED_NETWORK_INGRESS:            "RATE_LIMITED_NETWORK_INGRESS",
+	RATE_LIMITED_NETWORK_EGRESS:             "RATE_LIMITED_NETWORK_EGRESS",
+	RATE_LIMITED_MAX_CONNECTIONS:            "RATE_LIMITED_MAX_CONNECTIONS",
+	RATE_LIMITED_MAX_COMMANDS:               "RATE_LIMITED_MAX_COMMANDS",
+	SCOPE_SIZE_LIMIT_EXCEEDED:               "SCOPE_SIZE_LIMIT_EXCEEDED",
+	BUCKET_SIZE_LIMIT_EXCEEDED:              "BUCKET_SIZE_LIMIT_EXCEEDED",
+	UNKNOWN_COMMAND:                         "UNKNOWN_COMMAND",
+	ENOMEM:                                  "ENOMEM",
+	NOT_SUPPORTED:                           "NOT_SUPPORTED",
+	EINTERNAL:                               "EINTERNAL",
+	EBUSY:                                   "EBUSY",
+	ETMPFAIL:                                "ETMPFAIL",
+	XATTR_EINVAL:                            "XATTR_EINVAL",
+	UNKNOWN_COLLECTION:                      "UNKNOWN_COLLECTION",
+	NO_COLLECTIONS_MANIFEST:                 "NO_COLLECTIONS_MANIFEST",
+	CANNOT_APPLY_COLLECTIONS_MANIFEST:       "CANNOT_APPLY_COLLECTIONS_MANIFEST",
+	COLLECTIONS_MANIFEST_IS_AHEAD:           "COLLECTIONS_MANIFEST_IS_AHEAD",
+	UNKNOWN_SCOPE:                           "UNKNOWN_SCOPE",
+	DCP_STREAMID_INVALID:                    "DCP_STREAMID_INVALID",
+	DURABILITY_INVALID_LEVEL:                "DURABILITY_INVALID_LEVEL",
+	DURABILITY_IMPOSSIBLE:                   "DURABILITY_IMPOSSIBLE",
+	SYNC_WRITE_IN_PROGRESS:                  "SYNC_WRITE_IN_PROGRESS",
+	SYNC_WRITE_AMBIGUOUS:                    "SYNC_WRITE_AMBIGUOUS",
+	SYNC_WRITE_RECOMMIT_IN_PROGRESS:         "SYNC_WRITE_RECOMMIT_IN_PROGRESS",
+	SUBDOC_PATH_ENOENT:                      "SUBDOC_PATH_ENOENT",
+	SUBDOC_PATH_MISMATCH:                    "SUBDOC_PATH_MISMATCH",
+	SUBDOC_PATH_EINVAL:                      "SUBDOC_PATH_EINVAL",
+	SUBDOC_PATH_E2BIG:                       "SUBDOC_PATH_E2BIG",
+	SUBDOC_DOC_E2DEEP:                       "SUBDOC_DOC_E2DEEP",
+	SUBDOC_VALUE_CANTINSERT:                 "SUBDOC_VALUE_CANTINSERT",
+	SUBDOC_DOC_NOT_JSON:                     "SUBDOC_DOC_NOT_JSON",
+	SUBDOC_NUM_ERANGE:                       "SUBDOC_NUM_ERANGE",
+	SUBDOC_DELTA_EINVAL:                     "SUBDOC_DELTA_EINVAL",
+	SUBDOC_PATH_EEXISTS:                     "SUBDOC_PATH_EEXISTS",
+	SUBDOC_VALUE_ETOODEEP:                   "SUBDOC_VALUE_ETOODEEP",
+	SUBDOC_INVALID_COMBO:                    "SUBDOC_INVALID_COMBO",
+	SUBDOC_MULTI_PATH_FAILURE:               "SUBDOC_MULTI_PATH_FAILURE",
+	SUBDOC_SUCCESS_DELETED:                  "SUBDOC_SUCCESS_DELETED",
+	SUBDOC_XATTR_INVALID_FLAG_COMBO:         "SUBDOC_XATTR_INVALID_FLAG_COMBO",
+	SUBDOC_XATTR_INVALID_KEY_COMBO:          "SUBDOC_XATTR_INVALID_KEY_COMBO",
+	SUBDOC_XATTR_UNKNOWN_MACRO:              "SUBDOC_XATTR_UNKNOWN_MACRO",
+	SUBDOC_XATTR_UNKNOWN_VATTR:              "SUBDOC_XATTR_UNKNOWN_VATTR",
+	SUBDOC_XATTR_CANT_MODIFY_VATTR:          "SUBDOC_XATTR_CANT_MODIFY_VATTR",
+	SUBDOC_MULTI_PATH_FAILURE_DELETED:       "SUBDOC_MULTI_PATH_FAILURE_DELETED",
+	SUBDOC_INVALID_XATTR_ORDER:              "SUBDOC_INVALID_XATTR_ORDER",
+	SUBDOC_XATTR_UNKNOWN_VATTR_MACRO:        "SUBDOC_XATTR_UNKNOWN_VATTR_MACRO",
+	SUBDOC_CAN_ONLY_REVIVE_DELETED_DOCUMENT: "SUBDOC_CAN_ONLY_REVIVE_DELETED_DOCUMENT",
+	SUBDOC_DELETED_DOCUMENT_CANT_HAVE_VALUE: "SUBDOC_DELETED_DOCUMENT_CANT_HAVE_VALUE",
+}
+
+func (s Status) String() string {
+	if name, ok := statusNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("UNKNOWN_0x%04x", uint16(s))
+}
+
+//AUTH_CONTINUE and SUBDOC_SUCCESS_DELETED are part of a normal exchange and not counted as errors
+func (s Status) isError() bool {
+	return s != SUCCESS && s != AUTH_CONTINUE && s != SUBDOC_SUCCESS_DELETED
+}
@@ -0,0 +1,88 @@
+/*
+* Copyright (c) 2017 Couchbase, Inc.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/binary"
+	"io"
+	"testing"
+)
+
+func TestStatusCodes(t *testing.T) {
+	tests := []struct {
+		status Status
+		name   string
+		error  bool
+	}{
+		{SUCCESS, "SUCCESS", false},
+		{KEY_ENOENT, "KEY_ENOENT", true},
+		{NOT_MY_VBUCKET, "NOT_MY_VBUCKET", true},
+		{AUTH_CONTINUE, "AUTH_CONTINUE", false},
+		{ETMPFAIL, "ETMPFAIL", true},
+		{SUBDOC_MULTI_PATH_FAILURE, "SUBDOC_MULTI_PATH_FAILURE", true},
+		{SUBDOC_SUCCESS_DELETED, "SUBDOC_SUCCESS_DELETED", false},
+		{Status(0x00ff), "UNKNOWN_0x00ff", true},
+		{Status(0xffff), "UNKNOWN_0xffff", true},
+	}
+	for _, test := range tests {
+		if name := test.status.String(); name != test.name {
+			t.Errorf("expected %v for 0x%04x, got %v", test.name, uint16(test.status), name)
+		}
+		if test.status.isError() != test.error {
+			t.Errorf("expected isError of %v to be %v", test.name, test.error)
+		}
+	}
+}
+
+func TestStatusFrames(t *testing.T) {
+	withStatus := func(frame []byte, status uint16) []byte {
+		binary.BigEndian.PutUint16(frame[6:8], status)
+		return frame
+	}
+	hit := encode(0x81, GET, 1, []byte{0, 0, 0, 0}, "", []byte("value"))
+	miss := withStatus(encode(0x81, GET, 2, nil, "", []byte("Not found")), uint16(KEY_ENOENT))
+	//the same field carries the vbucket of a request
+	request := withStatus(encode(0x80, GET, 3, nil, "key", nil), 0x0086)
+	oversized := append([]byte(nil), miss...)
+	binary.BigEndian.PutUint32(oversized[8:12], 0x02000001)
+	tests := []struct {
+		name   string
+		frame  []byte
+		err    error
+		status Status
+	}{
+		{"success", hit, nil, SUCCESS},
+		{"key not found", miss, nil, KEY_ENOENT},
+		{"request vbucket", request, nil, SUCCESS},
+		{"truncated header", miss[:20], io.EOF, SUCCESS},
+		{"truncated value", miss[:len(miss)-3], io.EOF, KEY_ENOENT},
+		{"oversized body length", oversized, &ParseError{Kind: PARSE_ERROR_BODY_LENGTH}, SUCCESS},
+	}
+	for _, test := range tests {
+		command, err := decode(test.frame)
+		if !sameError(err, test.err) {
+			t.Errorf("%v: expected error %v, got %v", test.name, test.err, err)
+			continue
+		}
+		if command.status != test.status {
+			t.Errorf("%v: expected status %v, got %v", test.name, test.status, command.status)
+		}
+		if command.isComplete() != (err == nil) {
+			t.Errorf("%v: unexpected isComplete %v", test.name, command.isComplete())
+		}
+	}
+}
\ No newline at end of file
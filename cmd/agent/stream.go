@@ -17,67 +17,387 @@
 package main
 
 import (
+	"../../logger"
 	"bytes"
+	"github.com/google/gopacket"
+	"io"
+	"runtime/debug"
 	"sync"
 )
 
 type Stream struct {
-	mutex            *sync.Mutex
-	currentRequests  map[uint32]*Command
-	currentResponses map[uint32]*Command
-	currentCommand   *Command
-	latencyInfo      []LatencyInfo
+	mutex              *sync.Mutex
+	requests           map[gopacket.Flow]*requestQueue //outstanding requests by the direction they were sent in
+	pendingResponses   map[gopacket.Flow][]*Command    //responses parsed before their request, by the direction of the request
+	reorderedResponses uint64
+	unmatchedResponses uint64                     //responses which could not be paired with a request
+	currentCommands    map[gopacket.Flow]*Command //partially parsed command of each direction
+	resyncing          map[gopacket.Flow]bool     //directions which lost track of the command boundaries
+	latencyInfo        []LatencyInfo
+	gaps               uint64
+	skippedBytes       uint64
+	parseErrors        map[ParseErrorKind]uint64
+	resyncs            uint64
+	bucket             string
+	user               string
+	pendingUser        string //user of an authentication which has not completed yet
+	userAgent          string
+	clientConnectionId string //connection id chosen by the sdk, shows up in its logs
+	sdk                string
+	features           map[Feature]bool             //features negotiated with HELLO
+	manifests          map[string]map[uint32]string //collection names per bucket
+	hosts              map[gopacket.Flow]string     //sending host of each direction
+	client             string
+	notMyVbucket       uint64
+	configRevisions    []ConfigRevision
+	lastRevisions      map[string]ConfigRevision //last cluster map revision per bucket
+	pushMessages       []PushMessage
+	pendingPushes      map[uint32]int //index of the unanswered server requests in pushMessages by opaque
+	droppedPushes      uint64         //server requests past maxUnansweredOps
+	dcp                *DcpConnection //nil unless DCP traffic was seen on the connection
+	subdocPaths        map[subdocPathKey]*SubdocPathStats
+	recentReads        map[documentKey]bool //documents fetched in full
+	readModifyWrites   uint64
+	unansweredOps      []UnansweredOp
+	unanswered         uint64
+	likelyTimeouts     uint64                 //requests outstanding when the connection was closed
+	finished           map[gopacket.Flow]bool //directions whose FIN or RST was delivered
+	closed             bool
+	requestSequence    uint64
+	recorder           *Recorder //told about the latency of every op so slow ones can be preserved
+	logger             *logger.Logger
 }
 
 type LatencyInfo struct {
-	Opaque  uint32
-	Latency int64
-	Key     string
+	Opaque           uint32
+	Opcode           Opcode
+	Status           Status
+	Timestamp        int64
+	Latency          int64
+	Key              string
+	ServerDuration   int64
+	Durability       DurabilityLevel
+	ImpersonatedUser string
+	StreamId         uint16
+	RequestCas       uint64
+	ResponseCas      uint64
+	RequestDatatype  Datatype
+	ResponseDatatype Datatype
+	RequestSize      uint32
+	ResponseSize     uint32
+	Bucket           string
+	User             string
+	CollectionId     uint32
+	HasCollectionId  bool
+	Sdk              string
+	Vbucket          uint16
+	Inferred         bool //quiet request which was completed by a NOOP
 }
 
-func (stream *Stream) collect() {
-	for opaque, response := range stream.currentResponses {
-		if response.isComplete() {
-			if stream.currentResponses[opaque].opcode == IGNORED {
-				delete(stream.currentResponses, opaque)
+//UnansweredOp is a request which got no response within the unanswered timeout or before the
+//connection was closed
+type UnansweredOp struct {
+	Opaque           uint32
+	Opcode           Opcode
+	Key              string
+	Timestamp        int64
+	Age              int64
+	ConnectionClosed bool
+}
+
+const maxUnansweredOps = 10000 //per connection, further ops which may never be answered are only counted
+
+func NewStream() *Stream {
+	return &Stream{
+		requests:         make(map[gopacket.Flow]*requestQueue),
+		pendingResponses: make(map[gopacket.Flow][]*Command),
+		currentCommands:  make(map[gopacket.Flow]*Command),
+		resyncing:        make(map[gopacket.Flow]bool),
+		parseErrors:      make(map[ParseErrorKind]uint64),
+		features:         make(map[Feature]bool),
+		manifests:        make(map[string]map[uint32]string),
+		hosts:            make(map[gopacket.Flow]string),
+		finished:         make(map[gopacket.Flow]bool),
+		lastRevisions:    make(map[string]ConfigRevision),
+		pendingPushes:    make(map[uint32]int),
+		subdocPaths:      make(map[subdocPathKey]*SubdocPathStats),
+		recentReads:      make(map[documentKey]bool),
+		mutex:            &sync.Mutex{},
+	}
+}
+
+func (stream *Stream) queueOf(direction gopacket.Flow) *requestQueue {
+	queue, ok := stream.requests[direction]
+	if !ok {
+		queue = newRequestQueue()
+		stream.requests[direction] = queue
+	}
+	return queue
+}
+
+func (stream *Stream) handleRequest(direction gopacket.Flow, request *Command) {
+	queue := stream.queueOf(direction)
+	queue.push(request)
+
+	//the response may have been parsed first when the request was still waiting for its value
+	orphans := stream.pendingResponses[direction]
+	for i, response := range orphans {
+		if response.opaque == request.opaque && response.opcode == request.opcode {
+			stream.pendingResponses[direction] = append(orphans[:i:i], orphans[i+1:]...)
+			stream.match(queue, request, response)
+			return
+		}
+	}
+}
+
+func (stream *Stream) handleResponse(direction gopacket.Flow, response *Command) {
+	requestDirection := direction.Reverse()
+	queue := stream.queueOf(requestDirection)
+	if request := queue.match(response); request != nil {
+		stream.match(queue, request, response)
+		return
+	}
+	if queue.hasOpaque(response.opaque) {
+		//opaque collision between unrelated commands
+		stream.unmatchedResponses++
+		return
+	}
+	if len(stream.pendingResponses[requestDirection]) >= maxUnansweredOps {
+		stream.unmatchedResponses++
+		return
+	}
+	stream.pendingResponses[requestDirection] = append(stream.pendingResponses[requestDirection], response)
+}
+
+//match pairs a request with its response. Unless the client negotiated unordered execution the
+//server answers in the order the requests were sent, so a response overtaking an older request
+//only counts as reordered when the feature is on.
+func (stream *Stream) match(queue *requestQueue, request, response *Command) {
+	if oldest := queue.oldestAnswerable(); oldest != nil && oldest != request && !request.opcode.isQuiet() &&
+		stream.features[FEATURE_UNORDERED_EXECUTION] {
+		stream.reorderedResponses++
+	}
+	queue.remove(request)
+	stream.complete(request, response)
+	if request.opcode == NOOP {
+		stream.closeQuietRequests(queue, request, response)
+	}
+}
+
+//complete records the latency of a request and the response to it
+func (stream *Stream) complete(request, response *Command) {
+	latencyInfo := LatencyInfo{
+		Opaque:           request.opaque,
+		Opcode:           request.opcode,
+		Status:           response.status,
+		Timestamp:        request.captureTimeInNanos,
+		Latency:          response.captureTimeInNanos - request.captureTimeInNanos,
+		Key:              string(request.key),
+		ServerDuration:   -1,
+		Durability:       request.durabilityLevel,
+		ImpersonatedUser: request.impersonatedUser,
+		StreamId:         request.streamId,
+		RequestCas:       request.cas,
+		ResponseCas:      response.cas,
+		RequestDatatype:  request.datatype,
+		ResponseDatatype: response.datatype,
+		RequestSize:      request.valueLength,
+		ResponseSize:     response.valueLength,
+		Bucket:           request.bucket,
+		User:             request.user,
+		CollectionId:     request.collectionId,
+		HasCollectionId:  request.hasCollectionId,
+		Sdk:              request.sdk,
+		Vbucket:          request.vbucket,
+		Inferred:         response.inferred,
+	}
+	if response.status == NOT_MY_VBUCKET {
+		stream.notMyVbucket++
+	}
+	if response.hasServerDuration {
+		latencyInfo.ServerDuration = response.serverDuration
+	}
+	stream.latencyInfo = append(stream.latencyInfo, latencyInfo)
+	if stream.recorder != nil {
+		stream.recorder.HandleLatency(request.captureTimeInNanos, response.captureTimeInNanos)
+	}
+	stream.handleSessionResponse(request, response)
+	stream.handleDcpResponse(request, response)
+	if request.opcode.isSubdoc() {
+		stream.handleSubdocResponse(request, response)
+	}
+	stream.trackReadModifyWrite(request, response)
+}
+
+//closeQuietRequests completes the quiet requests sent before a NOOP. The server answers them in
+//order, so whatever did not get a response before the NOOP response succeeded silently.
+func (stream *Stream) closeQuietRequests(queue *requestQueue, noop, noopResponse *Command) {
+	for _, request := range queue.pending() {
+		if request.sequence > noop.sequence {
+			break
+		}
+		if !request.opcode.isQuiet() {
+			continue
+		}
+		queue.remove(request)
+		stream.complete(request, &Command{
+			state:              parseStateComplete,
+			commandType:        RESPONSE,
+			opcode:             request.opcode,
+			status:             request.opcode.quietStatus(),
+			opaque:             request.opaque,
+			captureTimeInNanos: noopResponse.captureTimeInNanos,
+			inferred:           true,
+		})
+	}
+}
+
+func (stream *Stream) unansweredRequest(request *Command, now int64, connectionClosed bool) {
+	stream.unanswered++
+	if len(stream.unansweredOps) < maxUnansweredOps {
+		stream.unansweredOps = append(stream.unansweredOps, UnansweredOp{
+			Opaque:           request.opaque,
+			Opcode:           request.opcode,
+			Key:              string(request.key),
+			Timestamp:        request.captureTimeInNanos,
+			Age:              now - request.captureTimeInNanos,
+			ConnectionClosed: connectionClosed,
+		})
+	}
+}
+
+//ExpireRequests drops the requests sent before olderThan which are still waiting for a response,
+//and the responses parsed before olderThan whose request never showed up
+func (stream *Stream) ExpireRequests(olderThan int64) {
+	for _, queue := range stream.requests {
+		for _, request := range queue.pending() {
+			if request.captureTimeInNanos >= olderThan {
+				break
 			}
-			if request, ok := stream.currentRequests[opaque]; !ok {
-				delete(stream.currentResponses, opaque)
+			stream.unansweredRequest(request, olderThan, false)
+			queue.remove(request)
+		}
+	}
+	for direction, orphans := range stream.pendingResponses {
+		var kept []*Command
+		for _, response := range orphans {
+			if response.captureTimeInNanos < olderThan {
+				stream.unmatchedResponses++
 			} else {
-				if request.opcode == IGNORED {
-					delete(stream.currentResponses, opaque)
-				} else {
-					latencyInfo := LatencyInfo{
-						Opaque:  opaque,
-						Latency: (response.captureTimeInNanos - request.captureTimeInNanos) / 1000,
-						Key:     string(request.key),
-					}
-					stream.latencyInfo = append(stream.latencyInfo, latencyInfo)
-					delete(stream.currentRequests, opaque)
-					delete(stream.currentResponses, opaque)
-				}
+				kept = append(kept, response)
 			}
 		}
+		stream.pendingResponses[direction] = kept
 	}
 }
 
-func (stream *Stream) HandlePacket(data []byte) {
-	if len(data) > 0 {
-		if stream.currentCommand == nil {
-			stream.currentCommand = NewCommand()
-		}
+//HandleEnd is called once the FIN or RST of a direction was delivered. A client may half-close
+//its side and still read the outstanding responses, so the connection only counts as closed
+//once both directions ended.
+func (stream *Stream) HandleEnd(direction gopacket.Flow, captureTimeInNanos int64) {
+	stream.finished[direction] = true
+	if stream.finished[direction.Reverse()] {
+		stream.HandleClose(captureTimeInNanos)
+	}
+}
 
-		if err := stream.currentCommand.ReadNewPacketData(bytes.NewBuffer(data)); err != nil {
-			return
+//HandleClose is called when the connection was reset or both sides closed it, clients commonly
+//close the connection after an op timed out so outstanding requests are counted as likely timeouts
+func (stream *Stream) HandleClose(captureTimeInNanos int64) {
+	if stream.closed {
+		return
+	}
+	stream.closed = true
+	for _, queue := range stream.requests {
+		for _, request := range queue.pending() {
+			stream.likelyTimeouts++
+			stream.unansweredRequest(request, captureTimeInNanos, true)
+			queue.remove(request)
 		}
-		if stream.currentCommand.isComplete() && stream.currentCommand.isResponse() {
-			stream.currentResponses[stream.currentCommand.opaque] = stream.currentCommand
-			stream.currentCommand = nil
-		} else if stream.currentCommand.isComplete() && !stream.currentCommand.isResponse() {
-			stream.currentRequests[stream.currentCommand.opaque] = stream.currentCommand
-			stream.currentCommand = nil
+	}
+}
+
+//HandleGap drops the partially parsed command of a direction when the reassembler could not
+//deliver some of its bytes. skipped is -1 when the capture joined the connection mid-stream,
+//nothing was lost then but the data does not start at a command boundary
+func (stream *Stream) HandleGap(direction gopacket.Flow, skipped int) {
+	if skipped > 0 {
+		stream.gaps++
+		stream.skippedBytes += uint64(skipped)
+	}
+	delete(stream.currentCommands, direction)
+	stream.resyncing[direction] = true
+}
+
+//resync drops bytes from data up to the next plausible command header
+func (stream *Stream) resync(direction gopacket.Flow, data []byte) *bytes.Buffer {
+	offset := findHeader(data)
+	if offset < 0 {
+		return &bytes.Buffer{}
+	}
+	delete(stream.resyncing, direction)
+	stream.resyncs++
+	return bytes.NewBuffer(data[offset:])
+}
+
+//HandlePacket parses every command contained in data, a command which is cut short by the end
+//of data is kept for the direction and completed by the following packets
+func (stream *Stream) HandlePacket(direction gopacket.Flow, data []byte, captureTimeInNanos int64) {
+	defer func() {
+		//a malformed packet must never take the agent down, treat it as a parse error but log the
+		//panic since it is a bug in a decoder
+		if r := recover(); r != nil {
+			if stream.logger != nil {
+				stream.logger.Error("Panic while parsing %v: %v\n%s", direction, r, debug.Stack())
+			}
+			stream.parseErrors[PARSE_ERROR_UNEXPECTED]++
+			delete(stream.currentCommands, direction)
+			stream.resyncing[direction] = true
 		}
+	}()
+
+	buffer := bytes.NewBuffer(data)
+	if stream.resyncing[direction] {
+		buffer = stream.resync(direction, data)
 	}
+	for buffer.Len() > 0 {
+		currentCommand := stream.currentCommands[direction]
+		if currentCommand == nil {
+			currentCommand = NewCommand(captureTimeInNanos)
+			stream.currentCommands[direction] = currentCommand
+		}
 
-	stream.collect()
+		if err := currentCommand.ReadNewPacketData(buffer); err == io.EOF {
+			break
+		} else if parseError, ok := err.(*ParseError); ok {
+			stream.parseErrors[parseError.Kind]++
+			delete(stream.currentCommands, direction)
+			stream.resyncing[direction] = true
+			//the next command may start anywhere after the first byte of the rejected header
+			pending := append(append([]byte(nil), parseError.Header[1:]...), buffer.Bytes()...)
+			buffer = stream.resync(direction, pending)
+			continue
+		}
+		switch currentCommand.commandType {
+		case RESPONSE:
+			stream.handleResponse(direction, currentCommand)
+		case REQUEST:
+			if stream.handleDcpRequest(currentCommand) {
+				break
+			}
+			if stream.client == "" {
+				//producers send DCP_NOOP requests as well, the first request tells the client
+				stream.client = stream.hosts[direction]
+			}
+			stream.requestSequence++
+			currentCommand.sequence = stream.requestSequence
+			stream.handleSessionRequest(currentCommand)
+			stream.handleRequest(direction, currentCommand)
+		case SERVER_REQUEST:
+			stream.handleServerRequest(currentCommand)
+		case CLIENT_RESPONSE:
+			stream.handleClientResponse(currentCommand)
+		}
+		delete(stream.currentCommands, direction)
+	}
 }
@@ -0,0 +1,102 @@
+/*
+* Copyright (c) 2017 Couchbase, Inc.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package main
+
+import (
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"net"
+	"reflect"
+	"testing"
+)
+
+func TestNotMyVbucket(t *testing.T) {
+	clientFlow := gopacket.NewFlow(layers.EndpointTCPPort, []byte{0xc3, 0x50}, []byte{0x2b, 0xca})
+	serverFlow := clientFlow.Reverse()
+	stream := NewStream()
+	stream.hosts[clientFlow] = net.IP{10, 0, 0, 1}.String()
+	stream.hosts[serverFlow] = net.IP{10, 0, 0, 2}.String()
+	for opaque, status := range []Status{SUCCESS, NOT_MY_VBUCKET, NOT_MY_VBUCKET} {
+		response := encode(MAGIC_RESPONSE, GET, uint32(opaque), nil, "", nil)
+		response[6], response[7] = byte(status>>8), byte(status)
+		stream.HandlePacket(clientFlow, encode(MAGIC_REQUEST, GET, uint32(opaque), nil, "key", nil), 1000)
+		stream.HandlePacket(serverFlow, response, 2000)
+	}
+	if len(stream.latencyInfo) != 3 {
+		t.Fatalf("expected 3 ops, got %v", len(stream.latencyInfo))
+	}
+	if stream.notMyVbucket != 2 || stream.client != "10.0.0.1" {
+		t.Errorf("expected 2 NOT_MY_VBUCKET from 10.0.0.1, got %v from %v", stream.notMyVbucket, stream.client)
+	}
+}
+
+func TestExpireRequests(t *testing.T) {
+	clientFlow := gopacket.NewFlow(layers.EndpointTCPPort, []byte{0xc3, 0x50}, []byte{0x2b, 0xca})
+	stream := NewStream()
+	stream.HandlePacket(clientFlow, encode(MAGIC_REQUEST, GET, 1, nil, "old", nil), 1000)
+	stream.HandlePacket(clientFlow, encode(MAGIC_REQUEST, GET, 2, nil, "recent", nil), 4000)
+	stream.ExpireRequests(3000)
+	stream.HandlePacket(clientFlow.Reverse(), encode(MAGIC_RESPONSE, GET, 2, nil, "", nil), 5000)
+	stream.HandlePacket(clientFlow.Reverse(), encode(MAGIC_RESPONSE, GET, 1, nil, "", nil), 6000)
+
+	expected := []UnansweredOp{{Opaque: 1, Opcode: GET, Key: "old", Timestamp: 1000, Age: 2000}}
+	if !reflect.DeepEqual(stream.unansweredOps, expected) || stream.unanswered != 1 {
+		t.Errorf("expected unanswered %+v, got %+v", expected, stream.unansweredOps)
+	}
+	if len(stream.latencyInfo) != 1 || stream.latencyInfo[0].Opaque != 2 {
+		t.Errorf("expected only the recent request to complete, got %+v", stream.latencyInfo)
+	}
+	if stream.likelyTimeouts != 0 {
+		t.Errorf("expected no likely timeouts on an open connection, got %v", stream.likelyTimeouts)
+	}
+}
+
+func TestQuietOpClosedByNoop(t *testing.T) {
+	clientFlow := gopacket.NewFlow(layers.EndpointTCPPort, []byte{0xc3, 0x50}, []byte{0x2b, 0xca})
+	stream := NewStream()
+	stream.HandlePacket(clientFlow, concat(encode(MAGIC_REQUEST, GETQ, 1, nil, "missing", nil),
+		encode(MAGIC_REQUEST, GETQ, 2, nil, "present", nil), encode(MAGIC_REQUEST, SETQ, 3, make([]byte, 8), "stored", nil),
+		encode(MAGIC_REQUEST, NOOP, 4, nil, "", nil), encode(MAGIC_REQUEST, GETQ, 5, nil, "later", nil)), 1000)
+	stream.HandlePacket(clientFlow.Reverse(), concat(encode(MAGIC_RESPONSE, GETQ, 2, make([]byte, 4), "", []byte("value")),
+		encode(MAGIC_RESPONSE, NOOP, 4, nil, "", nil)), 3000)
+
+	type op struct {
+		status   Status
+		inferred bool
+	}
+	ops := make(map[uint32]op)
+	for _, latencyInfo := range stream.latencyInfo {
+		ops[latencyInfo.Opaque] = op{latencyInfo.Status, latencyInfo.Inferred}
+		if latencyInfo.Latency != 2000 {
+			t.Errorf("expected a latency of 2000 for opaque %v, got %v", latencyInfo.Opaque, latencyInfo.Latency)
+		}
+	}
+	expected := map[uint32]op{1: {KEY_ENOENT, true}, 2: {SUCCESS, false}, 3: {SUCCESS, true}, 4: {SUCCESS, false}}
+	if !reflect.DeepEqual(ops, expected) {
+		t.Errorf("expected ops %v, got %v", expected, ops)
+	}
+
+	//the inferred miss is neither an error nor counted with the misses the server reported
+	agent := &Agent{streams: map[uint64]*Stream{1: stream}}
+	statusCounts, opcodeErrors := agent.GetErrorStats()
+	if statusCounts["KEY_ENOENT_INFERRED"] != 1 || statusCounts["KEY_ENOENT"] != 0 {
+		t.Errorf("expected a single inferred miss, got %v", statusCounts)
+	}
+	if getq := opcodeErrors["GETQ"]; getq.Total != 2 || getq.Errors != 0 {
+		t.Errorf("expected 2 GETQ without errors, got %+v", getq)
+	}
+}
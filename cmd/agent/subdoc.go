@@ -0,0 +1,225 @@
+/*
+* Copyright (c) 2017 Couchbase, Inc.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package main
+
+import "encoding/binary"
+
+const (
+	subdocFlagXattrPath = 0x04
+	maxRecentReads      = 10000
+)
+
+//SubdocSpec is one path operation of a sub-document command
+type SubdocSpec struct {
+	Opcode      Opcode
+	Flags       uint8
+	Path        string
+	Status      Status
+	ValueLength uint32 //value sent for mutations, value returned for lookups
+}
+
+func (spec *SubdocSpec) isXattr() bool {
+	return spec.Flags&subdocFlagXattrPath != 0
+}
+
+type subdocPathKey struct {
+	opcode Opcode
+	path   string
+	xattr  bool
+}
+
+type SubdocPathStats struct {
+	Count        uint64
+	Errors       uint64
+	TotalLatency int64
+	MaxLatency   int64
+	ValueBytes   uint64
+}
+
+//documentKey identifies a document on a connection for read-modify-write detection
+type documentKey struct {
+	bucket       string
+	collectionId uint32
+	key          string
+}
+
+func (op Opcode) isSubdocSinglePath() bool {
+	return op >= SUBDOC_GET && op <= SUBDOC_COUNTER || op == SUBDOC_GET_COUNT || op == SUBDOC_REPLACE_BODY_XATTR
+}
+
+func (op Opcode) isSubdoc() bool {
+	return op.isSubdocSinglePath() || op == SUBDOC_MULTI_LOOKUP || op == SUBDOC_MULTI_MUTATION
+}
+
+//decodeSubdocSpecs reads the path specs of a sub-document request and the per path status of
+//its response
+func decodeSubdocSpecs(request, response *Command) []SubdocSpec {
+	switch request.opcode {
+	case SUBDOC_MULTI_LOOKUP:
+		specs := decodeLookupSpecs(request.value)
+		applyLookupResults(specs, response)
+		return specs
+	case SUBDOC_MULTI_MUTATION:
+		specs := decodeMutationSpecs(request.value)
+		applyMutationResults(specs, response)
+		return specs
+	}
+
+	//single path commands carry the path length and flags in the extras and the path in the value
+	if len(request.extras) < 3 {
+		return nil
+	}
+	pathLength := int(binary.BigEndian.Uint16(request.extras))
+	if pathLength > len(request.value) {
+		return nil
+	}
+	spec := SubdocSpec{
+		Opcode:      request.opcode,
+		Flags:       request.extras[2],
+		Path:        string(request.value[:pathLength]),
+		Status:      response.status,
+		ValueLength: uint32(len(request.value) - pathLength),
+	}
+	if spec.Opcode == SUBDOC_GET || spec.Opcode == SUBDOC_GET_COUNT {
+		spec.ValueLength = response.valueLength
+	}
+	return []SubdocSpec{spec}
+}
+
+//lookup spec: opcode:1 flags:1 pathlen:2 path
+func decodeLookupSpecs(value []byte) []SubdocSpec {
+	var specs []SubdocSpec
+	for len(value) >= 4 {
+		pathLength := int(binary.BigEndian.Uint16(value[2:4]))
+		if 4+pathLength > len(value) {
+			break
+		}
+		specs = append(specs, SubdocSpec{
+			Opcode: Opcode(value[0]),
+			Flags:  value[1],
+			Path:   string(value[4 : 4+pathLength]),
+		})
+		value = value[4+pathLength:]
+	}
+	return specs
+}
+
+//mutation spec: opcode:1 flags:1 pathlen:2 valuelen:4 path value
+func decodeMutationSpecs(value []byte) []SubdocSpec {
+	var specs []SubdocSpec
+	for len(value) >= 8 {
+		pathLength := int(binary.BigEndian.Uint16(value[2:4]))
+		valueLength := binary.BigEndian.Uint32(value[4:8])
+		if 8+pathLength+int(valueLength) > len(value) {
+			break
+		}
+		specs = append(specs, SubdocSpec{
+			Opcode:      Opcode(value[0]),
+			Flags:       value[1],
+			Path:        string(value[8 : 8+pathLength]),
+			ValueLength: valueLength,
+		})
+		value = value[8+pathLength+int(valueLength):]
+	}
+	return specs
+}
+
+//lookup results follow the order of the specs: status:2 valuelen:4 value
+func applyLookupResults(specs []SubdocSpec, response *Command) {
+	if response.status != SUCCESS && response.status != SUBDOC_MULTI_PATH_FAILURE &&
+		response.status != SUBDOC_MULTI_PATH_FAILURE_DELETED && response.status != SUBDOC_SUCCESS_DELETED {
+		//the document could not be accessed at all
+		for i := range specs {
+			specs[i].Status = response.status
+		}
+		return
+	}
+	value := response.value
+	for i := range specs {
+		if len(value) < 6 {
+			return
+		}
+		specs[i].Status = Status(binary.BigEndian.Uint16(value[0:2]))
+		specs[i].ValueLength = binary.BigEndian.Uint32(value[2:6])
+		if 6+int(specs[i].ValueLength) > len(value) {
+			return
+		}
+		value = value[6+int(specs[i].ValueLength):]
+	}
+}
+
+//mutation results are only sent for the specs which return a value: index:1 status:2 valuelen:4 value,
+//a failed mutation returns index:1 status:2 of the first failing spec
+func applyMutationResults(specs []SubdocSpec, response *Command) {
+	switch response.status {
+	case SUCCESS, SUBDOC_SUCCESS_DELETED:
+		for i := range specs {
+			specs[i].Status = SUCCESS
+		}
+	case SUBDOC_MULTI_PATH_FAILURE, SUBDOC_MULTI_PATH_FAILURE_DELETED:
+		if len(response.value) >= 3 && int(response.value[0]) < len(specs) {
+			specs[response.value[0]].Status = Status(binary.BigEndian.Uint16(response.value[1:3]))
+		}
+	default:
+		for i := range specs {
+			specs[i].Status = response.status
+		}
+	}
+}
+
+//handleSubdocResponse attributes the latency of a sub-document command to each of its paths
+func (stream *Stream) handleSubdocResponse(request, response *Command) {
+	latency := response.captureTimeInNanos - request.captureTimeInNanos
+	for _, spec := range decodeSubdocSpecs(request, response) {
+		key := subdocPathKey{spec.Opcode, spec.Path, spec.isXattr()}
+		stats := stream.subdocPaths[key]
+		if stats == nil {
+			stats = &SubdocPathStats{}
+			stream.subdocPaths[key] = stats
+		}
+		stats.Count++
+		if spec.Status.isError() {
+			stats.Errors++
+		}
+		stats.TotalLatency += latency
+		if latency > stats.MaxLatency {
+			stats.MaxLatency = latency
+		}
+		stats.ValueBytes += uint64(spec.ValueLength)
+	}
+}
+
+//trackReadModifyWrite flags a full document read followed by a CAS replace of the same document,
+//a sub-document mutation could have changed the document without transferring it twice
+func (stream *Stream) trackReadModifyWrite(request, response *Command) {
+	if response.status != SUCCESS {
+		return
+	}
+	key := documentKey{request.bucket, request.collectionId, string(request.key)}
+	switch request.opcode {
+	case GET, GETK, GAT, GET_LOCKED, GET_REPLICA:
+		if len(stream.recentReads) >= maxRecentReads {
+			stream.recentReads = make(map[documentKey]bool)
+		}
+		stream.recentReads[key] = true
+	case SET, REPLACE:
+		if stream.recentReads[key] && request.cas != 0 {
+			stream.readModifyWrites++
+			delete(stream.recentReads, key)
+		}
+	}
+}
\ No newline at end of file
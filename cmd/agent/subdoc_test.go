@@ -0,0 +1,84 @@
+/*
+* Copyright (c) 2017 Couchbase, Inc.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+//lookupSpec, mutationSpec and lookupResult encode the path sections of multi path commands
+func lookupSpec(opcode Opcode, flags uint8, path string) []byte {
+	return append([]byte{byte(opcode), flags, 0, byte(len(path))}, path...)
+}
+
+func mutationSpec(opcode Opcode, path string, value string) []byte {
+	spec := []byte{byte(opcode), 0, 0, byte(len(path)), 0, 0, 0, byte(len(value))}
+	return append(append(spec, path...), value...)
+}
+
+func lookupResult(status Status, value string) []byte {
+	return append([]byte{byte(status >> 8), byte(status), 0, 0, 0, byte(len(value))}, value...)
+}
+
+func TestDecodeSubdocSpecs(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  *Command
+		response *Command
+		specs    []SubdocSpec
+	}{
+		{"single path", &Command{opcode: SUBDOC_DICT_UPSERT, extras: []byte{0, 4, 0}, value: []byte("name\"bob\"")},
+			&Command{status: SUCCESS}, []SubdocSpec{{Opcode: SUBDOC_DICT_UPSERT, Path: "name", Status: SUCCESS, ValueLength: 5}}},
+		{"single path get", &Command{opcode: SUBDOC_GET, extras: []byte{0, 4, subdocFlagXattrPath}, value: []byte("_txn")},
+			&Command{status: SUCCESS, valueLength: 12}, []SubdocSpec{{Opcode: SUBDOC_GET, Flags: subdocFlagXattrPath,
+				Path: "_txn", Status: SUCCESS, ValueLength: 12}}},
+		{"truncated extras", &Command{opcode: SUBDOC_GET, extras: []byte{0, 4}, value: []byte("name")},
+			&Command{status: SUCCESS}, nil},
+		{"oversized path length", &Command{opcode: SUBDOC_GET, extras: []byte{0, 5, 0}, value: []byte("name")},
+			&Command{status: SUCCESS}, nil},
+		{"lookup", &Command{opcode: SUBDOC_MULTI_LOOKUP, value: concat(lookupSpec(SUBDOC_GET, 0, "name"),
+			lookupSpec(SUBDOC_EXISTS, 0, "age"))}, &Command{status: SUBDOC_MULTI_PATH_FAILURE,
+			value: concat(lookupResult(SUCCESS, "\"bob\""), lookupResult(SUBDOC_PATH_ENOENT, ""))},
+			[]SubdocSpec{{Opcode: SUBDOC_GET, Path: "name", Status: SUCCESS, ValueLength: 5},
+				{Opcode: SUBDOC_EXISTS, Path: "age", Status: SUBDOC_PATH_ENOENT}}},
+		{"truncated lookup spec", &Command{opcode: SUBDOC_MULTI_LOOKUP, value: concat(lookupSpec(SUBDOC_GET, 0, "name"),
+			lookupSpec(SUBDOC_GET, 0, "age")[:5])}, &Command{status: SUCCESS, value: lookupResult(SUCCESS, "\"bob\"")},
+			[]SubdocSpec{{Opcode: SUBDOC_GET, Path: "name", Status: SUCCESS, ValueLength: 5}}},
+		{"truncated lookup result", &Command{opcode: SUBDOC_MULTI_LOOKUP, value: concat(lookupSpec(SUBDOC_GET, 0, "name"),
+			lookupSpec(SUBDOC_GET, 0, "age"))}, &Command{status: SUCCESS, value: lookupResult(SUCCESS, "\"bob\"")[:8]},
+			[]SubdocSpec{{Opcode: SUBDOC_GET, Path: "name", Status: SUCCESS, ValueLength: 5},
+				{Opcode: SUBDOC_GET, Path: "age"}}},
+		{"document not found", &Command{opcode: SUBDOC_MULTI_LOOKUP, value: lookupSpec(SUBDOC_GET, 0, "name")},
+			&Command{status: KEY_ENOENT}, []SubdocSpec{{Opcode: SUBDOC_GET, Path: "name", Status: KEY_ENOENT}}},
+		{"mutation", &Command{opcode: SUBDOC_MULTI_MUTATION, value: concat(mutationSpec(SUBDOC_DICT_ADD, "name", "\"bob\""),
+			mutationSpec(SUBDOC_DELETE, "age", ""))}, &Command{status: SUBDOC_MULTI_PATH_FAILURE,
+			value: []byte{0, byte(SUBDOC_PATH_EEXISTS >> 8), byte(SUBDOC_PATH_EEXISTS)}},
+			[]SubdocSpec{{Opcode: SUBDOC_DICT_ADD, Path: "name", Status: SUBDOC_PATH_EEXISTS, ValueLength: 5},
+				{Opcode: SUBDOC_DELETE, Path: "age"}}},
+		{"failure index out of range", &Command{opcode: SUBDOC_MULTI_MUTATION, value: mutationSpec(SUBDOC_DELETE, "age", "")},
+			&Command{status: SUBDOC_MULTI_PATH_FAILURE, value: []byte{3, 0, byte(SUBDOC_PATH_ENOENT)}},
+			[]SubdocSpec{{Opcode: SUBDOC_DELETE, Path: "age"}}},
+		{"oversized mutation value length", &Command{opcode: SUBDOC_MULTI_MUTATION,
+			value: mutationSpec(SUBDOC_DICT_ADD, "name", "\"bob\"")[:12]}, &Command{status: SUCCESS}, nil},
+	}
+	for _, test := range tests {
+		if specs := decodeSubdocSpecs(test.request, test.response); !reflect.DeepEqual(specs, test.specs) {
+			t.Errorf("%v: expected %+v, got %+v", test.name, test.specs, specs)
+		}
+	}
+}
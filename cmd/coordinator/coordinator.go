@@ -33,6 +33,7 @@ import (
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -52,11 +53,27 @@ type Coordinator struct {
 }
 
 type AgentInfo struct {
-	index    int
-	hostname string
-	conn     *grpc.ClientConn
-	client   pb.AgentServiceClient
-	results  map[string]*pb.AgentResultsResponse_CaptureInfo
+	index                int
+	hostname             string
+	conn                 *grpc.ClientConn
+	client               pb.AgentServiceClient
+	results              map[string]*pb.AgentResultsResponse_CaptureInfo
+	statusCounts         map[string]uint64
+	opcodeErrors         map[string]*pb.AgentResultsResponse_OpcodeErrors
+	sdkLatencies         map[string]*pb.AgentResultsResponse_LatencySummary
+	connections          map[string]*pb.AgentResultsResponse_ConnectionInfo
+	vbucketLatencies     map[string]*pb.AgentResultsResponse_LatencySummary
+	notMyVbucketByClient map[string]uint64
+	configRevisions      []*pb.AgentResultsResponse_ConfigRevision
+	pushMessages         []*pb.AgentResultsResponse_PushMessage
+	dcpConnections       map[string]*pb.AgentResultsResponse_DcpConnection
+	subdocPaths          []*pb.AgentResultsResponse_SubdocPath
+	readModifyWrites     map[string]uint64
+	unansweredOps        []*pb.AgentResultsResponse_UnansweredOp
+	captureStats         []*pb.AgentResultsResponse_CaptureStats
+	requestSizes         map[string]*pb.AgentResultsResponse_SizeHistogram
+	responseSizes        map[string]*pb.AgentResultsResponse_SizeHistogram
+	collectionLatencies  map[string]*pb.AgentResultsResponse_LatencySummary
 }
 
 type LatencyInfo struct {
@@ -90,6 +107,78 @@ func (c *Coordinator) homeHandler(w http.ResponseWriter, r *http.Request) {
 			c.shutdown()
 		}
 
+		errorsJson, err := c.getErrorRatesFromDb()
+		if err != nil {
+			c.logger.Error("Unable to get error rates from db due to %v", err)
+			os.Exit(1)
+		}
+
+		sdksJson, err := c.getSdkLatenciesFromDb()
+		if err != nil {
+			c.logger.Error("Unable to get sdk latencies from db due to %v", err)
+			os.Exit(1)
+		}
+
+		connectionsJson, err := c.getConnectionsFromDb()
+		if err != nil {
+			c.logger.Error("Unable to get connections from db due to %v", err)
+			os.Exit(1)
+		}
+
+		vbucketsJson, err := c.getVbucketStatsFromDb()
+		if err != nil {
+			c.logger.Error("Unable to get vbucket stats from db due to %v", err)
+			os.Exit(1)
+		}
+
+		revisionsJson, err := c.getConfigRevisionsFromDb()
+		if err != nil {
+			c.logger.Error("Unable to get config revisions from db due to %v", err)
+			os.Exit(1)
+		}
+
+		pushJson, err := c.getPushMessagesFromDb()
+		if err != nil {
+			c.logger.Error("Unable to get push messages from db due to %v", err)
+			os.Exit(1)
+		}
+
+		dcpJson, err := c.getDcpConnectionsFromDb()
+		if err != nil {
+			c.logger.Error("Unable to get dcp connections from db due to %v", err)
+			os.Exit(1)
+		}
+
+		subdocJson, err := c.getSubdocStatsFromDb()
+		if err != nil {
+			c.logger.Error("Unable to get subdoc stats from db due to %v", err)
+			os.Exit(1)
+		}
+
+		unansweredJson, err := c.getUnansweredOpsFromDb()
+		if err != nil {
+			c.logger.Error("Unable to get unanswered ops from db due to %v", err)
+			os.Exit(1)
+		}
+
+		captureStatsJson, err := c.getCaptureStatsFromDb()
+		if err != nil {
+			c.logger.Error("Unable to get capture stats from db due to %v", err)
+			os.Exit(1)
+		}
+
+		sizesJson, err := c.getSizeStatsFromDb()
+		if err != nil {
+			c.logger.Error("Unable to get size stats from db due to %v", err)
+			os.Exit(1)
+		}
+
+		collectionsJson, err := c.getCollectionLatenciesFromDb()
+		if err != nil {
+			c.logger.Error("Unable to get collection latencies from db due to %v", err)
+			os.Exit(1)
+		}
+
 		buffer.WriteString("<script type=\"text/javascript\">")
 		buffer.WriteString("var data=")
 		buffer.WriteString(jsonStr)
@@ -99,6 +188,42 @@ func (c *Coordinator) homeHandler(w http.ResponseWriter, r *http.Request) {
 		buffer.WriteString(";")
 		buffer.WriteString("var agents=")
 		buffer.WriteString(string(agentsJson))
+		buffer.WriteString(";")
+		buffer.WriteString("var errorRates=")
+		buffer.WriteString(errorsJson)
+		buffer.WriteString(";")
+		buffer.WriteString("var sdkLatencies=")
+		buffer.WriteString(sdksJson)
+		buffer.WriteString(";")
+		buffer.WriteString("var connections=")
+		buffer.WriteString(connectionsJson)
+		buffer.WriteString(";")
+		buffer.WriteString("var vbuckets=")
+		buffer.WriteString(vbucketsJson)
+		buffer.WriteString(";")
+		buffer.WriteString("var configRevisions=")
+		buffer.WriteString(revisionsJson)
+		buffer.WriteString(";")
+		buffer.WriteString("var pushMessages=")
+		buffer.WriteString(pushJson)
+		buffer.WriteString(";")
+		buffer.WriteString("var dcpConnections=")
+		buffer.WriteString(dcpJson)
+		buffer.WriteString(";")
+		buffer.WriteString("var subdoc=")
+		buffer.WriteString(subdocJson)
+		buffer.WriteString(";")
+		buffer.WriteString("var unansweredOps=")
+		buffer.WriteString(unansweredJson)
+		buffer.WriteString(";")
+		buffer.WriteString("var captureStats=")
+		buffer.WriteString(captureStatsJson)
+		buffer.WriteString(";")
+		buffer.WriteString("var sizes=")
+		buffer.WriteString(sizesJson)
+		buffer.WriteString(";")
+		buffer.WriteString("var collectionLatencies=")
+		buffer.WriteString(collectionsJson)
 		buffer.WriteString("</script>")
 		buffer.Write(html)
 
@@ -135,13 +260,13 @@ func (c *Coordinator) setupStore() {
 	for i := 0; i < len(c.agentsInfo); i++ {
 		agent := c.agentsInfo["agent"+strconv.Itoa(i)]
 		cols += fmt.Sprint("agent", agent.index)
-		cols += " text"
+		cols += " integer"
 		if agent.index < (len(c.agentsInfo) - 1) {
 			cols += ", "
 		}
 	}
 
-	sqlStmt := fmt.Sprintf("create table CaptureResults (opaque_streamId text not null, timestamp integer, %v); delete from CaptureResults;", cols)
+	sqlStmt := fmt.Sprintf("create table CaptureResults (opaque_streamId text not null, timestamp integer, bucket text, collection text, vbucket integer, opcode text, status text, serverduration integer, requestsize integer, responsesize integer, largedocument integer, %v); delete from CaptureResults;", cols)
 	_, err = db.Exec(sqlStmt)
 	if err != nil {
 		c.logger.Error("%q: %s\n", err, sqlStmt)
@@ -159,8 +284,37 @@ func (c *Coordinator) setupStore() {
 		}
 	}
 
-	statementStr := fmt.Sprintf("insert into CaptureResults(opaque_streamId, timestamp, %v) values(?, ?, %v)", fieldStr, argsStr)
+	statementStr := fmt.Sprintf("insert into CaptureResults(opaque_streamId, timestamp, bucket, collection, vbucket, opcode, status, serverduration, requestsize, responsesize, largedocument, %v) values(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, %v)", fieldStr, argsStr)
 	c.insertStatementStr = statementStr
+
+	sqlStmt = `create table OpcodeErrors (timestamp integer, agent text, opcode text, total integer, errors integer);
+	create table StatusCounts (timestamp integer, agent text, status text, count integer);
+	create table SdkLatencies (timestamp integer, agent text, sdk text, count integer, total integer, max integer);
+	create table Connections (timestamp integer, agent text, connection text, useragent text, features text);
+	create table VbucketLatencies (timestamp integer, agent text, vbucket integer, count integer, total integer, max integer);
+	create table NotMyVbucket (timestamp integer, agent text, client text, count integer);
+	create table ConfigRevisions (timestamp integer, agent text, bucket text, revepoch integer, rev integer, source text,
+	nodes text, topologychange integer);
+	create table PushMessages (timestamp integer, agent text, opcode text, key text, status text, latency integer);
+	create table DcpConnections (timestamp integer, agent text, connection text, name text, streams integer, items integer,
+	seqnolag integer, bytessent integer, maxunackedbytes integer, acklag integer, acklagsamples integer, maxacklag integer,
+	rollbacks integer);
+	create table SubdocPaths (timestamp integer, agent text, opcode text, path text, xattr integer, count integer,
+	errors integer, total integer, max integer, bytes integer);
+	create table ReadModifyWrites (timestamp integer, agent text, client text, count integer);
+	create table UnansweredOps (timestamp integer, agent text, connection text, opcode text, key text, age integer,
+	connectionclosed integer);
+	create table CaptureStats (timestamp integer, agent text, device text, sniffer text, received integer, dropped integer,
+	ifdropped integer, error text);
+	create table SizeStats (timestamp integer, agent text, opcode text, direction text, count integer, total integer,
+	max integer, largedocuments integer);
+	create table SizeBuckets (timestamp integer, agent text, opcode text, direction text, bucket integer, count integer);
+	create table CollectionLatencies (timestamp integer, agent text, collection text, count integer, total integer, max integer);`
+	_, err = db.Exec(sqlStmt)
+	if err != nil {
+		c.logger.Error("%q: %s\n", err, sqlStmt)
+		c.shutdown()
+	}
 	c.db = db
 }
 
@@ -171,7 +325,10 @@ func (c *Coordinator) storeFlusher() {
 		if currentTime < maxHistoryTime {
 			time.Sleep(time.Second * time.Duration(maxHistoryTime-currentTime))
 		}
-		sqlStmt := `delete from CaptureResults;`
+		sqlStmt := `delete from CaptureResults; delete from OpcodeErrors; delete from StatusCounts; delete from SdkLatencies; delete from Connections; delete from VbucketLatencies; delete from NotMyVbucket;
+		delete from ConfigRevisions; delete from PushMessages; delete from DcpConnections;
+		delete from SubdocPaths; delete from ReadModifyWrites; delete from UnansweredOps; delete from CaptureStats;
+		delete from SizeStats; delete from SizeBuckets; delete from CollectionLatencies;`
 		_, err := c.db.Exec(sqlStmt)
 		if err != nil {
 			c.logger.Error("Cannot execute %q: %s\n", err, sqlStmt)
@@ -221,6 +378,14 @@ func (c *Coordinator) StartCapture() {
 	wg.Wait()
 }
 
+//insert adds a row to the store, the coordinator gives up when the store cannot be written
+func (c *Coordinator) insert(tx *sql.Tx, query string, args ...interface{}) {
+	if _, err := tx.Exec(query, args...); err != nil {
+		c.logger.Error("Error executing insert %v", err)
+		c.shutdown()
+	}
+}
+
 func (c *Coordinator) mergeAndStore() {
 	tx, err := c.db.Begin()
 	if err != nil {
@@ -242,12 +407,28 @@ func (c *Coordinator) mergeAndStore() {
 	timestamp := time.Now().Unix() * 1000
 
 	for rowKey, row := range agentsInfo[0].results {
-		lat, _ := strconv.ParseInt(row.Oplatency, 10, 64)
-		c.histogram.RecordValue(lat)
+		c.histogram.RecordValue(row.LatencyInNanos)
+		rowTimestamp := timestamp
+		if row.TimestampInNanos > 0 {
+			rowTimestamp = row.TimestampInNanos / int64(time.Millisecond)
+		}
+		var serverDuration interface{}
+		if row.ServerDurationInNanos > 0 {
+			serverDuration = row.ServerDurationInNanos
+		}
 		var args []interface{}
 		args = append(args, rowKey)
-		args = append(args, timestamp)
-		args = append(args, row.Oplatency)
+		args = append(args, rowTimestamp)
+		args = append(args, row.Bucket)
+		args = append(args, row.Collection)
+		args = append(args, row.Vbucket)
+		args = append(args, row.Opcode)
+		args = append(args, row.Status)
+		args = append(args, serverDuration)
+		args = append(args, row.RequestSize)
+		args = append(args, row.ResponseSize)
+		args = append(args, row.LargeDocument)
+		args = append(args, row.LatencyInNanos)
 		foundInOtherAgents := true
 
 		if len(agentsInfo) > 0 {
@@ -256,9 +437,8 @@ func (c *Coordinator) mergeAndStore() {
 		for i := 1; i < len(agentsInfo); i++ {
 			agent := agentsInfo[i]
 			if row := agent.results[rowKey]; row != nil {
-				args = append(args, row.Oplatency)
-				lat, _ := strconv.ParseInt(row.Oplatency, 10, 64)
-				c.histogram.RecordValue(lat)
+				args = append(args, row.LatencyInNanos)
+				c.histogram.RecordValue(row.LatencyInNanos)
 				foundInOtherAgents = true
 			}
 		}
@@ -273,8 +453,120 @@ func (c *Coordinator) mergeAndStore() {
 		}
 	}
 
+	for _, agentInfo := range agentsInfo {
+		agent := fmt.Sprint("agent", agentInfo.index)
+		for opcode, counts := range agentInfo.opcodeErrors {
+			c.insert(tx, "insert into OpcodeErrors(timestamp, agent, opcode, total, errors) values(?, ?, ?, ?, ?)",
+				timestamp, agent, opcode, counts.Total, counts.Errors)
+		}
+		for status, count := range agentInfo.statusCounts {
+			c.insert(tx, "insert into StatusCounts(timestamp, agent, status, count) values(?, ?, ?, ?)",
+				timestamp, agent, status, count)
+		}
+		for sdk, latency := range agentInfo.sdkLatencies {
+			c.insert(tx, "insert into SdkLatencies(timestamp, agent, sdk, count, total, max) values(?, ?, ?, ?, ?, ?)",
+				timestamp, agent, sdk, latency.Count, latency.TotalLatencyInNanos, latency.MaxLatencyInNanos)
+		}
+		for vbucket, latency := range agentInfo.vbucketLatencies {
+			c.insert(tx, "insert into VbucketLatencies(timestamp, agent, vbucket, count, total, max) values(?, ?, ?, ?, ?, ?)",
+				timestamp, agent, vbucket, latency.Count, latency.TotalLatencyInNanos, latency.MaxLatencyInNanos)
+		}
+		for client, count := range agentInfo.notMyVbucketByClient {
+			c.insert(tx, "insert into NotMyVbucket(timestamp, agent, client, count) values(?, ?, ?, ?)",
+				timestamp, agent, client, count)
+		}
+		for _, revision := range agentInfo.configRevisions {
+			c.insert(tx, `insert into ConfigRevisions(timestamp, agent, bucket, revepoch, rev, source, nodes, topologychange)
+			values(?, ?, ?, ?, ?, ?, ?, ?)`, revision.TimestampInNanos/int64(time.Millisecond), agent, revision.Bucket,
+				revision.RevEpoch, revision.Rev, revision.Source, strings.Join(revision.Nodes, ","), revision.TopologyChange)
+		}
+		for _, message := range agentInfo.pushMessages {
+			var latency interface{}
+			if message.Answered {
+				latency = message.LatencyInNanos
+			}
+			c.insert(tx, "insert into PushMessages(timestamp, agent, opcode, key, status, latency) values(?, ?, ?, ?, ?, ?)",
+				message.TimestampInNanos/int64(time.Millisecond), agent, message.Opcode, message.Key, message.Status, latency)
+		}
+		for _, path := range agentInfo.subdocPaths {
+			c.insert(tx, `insert into SubdocPaths(timestamp, agent, opcode, path, xattr, count, errors, total, max, bytes)
+			values(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`, timestamp, agent, path.Opcode, path.Path, path.Xattr, path.Count, path.Errors,
+				path.TotalLatencyInNanos, path.MaxLatencyInNanos, path.ValueBytes)
+		}
+		for _, op := range agentInfo.unansweredOps {
+			c.insert(tx, `insert into UnansweredOps(timestamp, agent, connection, opcode, key, age, connectionclosed)
+			values(?, ?, ?, ?, ?, ?, ?)`, op.TimestampInNanos/int64(time.Millisecond), agent, op.Connection, op.Opcode, op.Key,
+				op.AgeInNanos, op.ConnectionClosed)
+		}
+		for _, stats := range agentInfo.captureStats {
+			c.insert(tx, `insert into CaptureStats(timestamp, agent, device, sniffer, received, dropped, ifdropped, error)
+			values(?, ?, ?, ?, ?, ?, ?, ?)`, timestamp, agent, stats.Device, stats.Sniffer, stats.Received, stats.Dropped,
+				stats.IfDropped, stats.Error)
+		}
+		for client, count := range agentInfo.readModifyWrites {
+			c.insert(tx, "insert into ReadModifyWrites(timestamp, agent, client, count) values(?, ?, ?, ?)",
+				timestamp, agent, client, count)
+		}
+		for streamKey, connection := range agentInfo.dcpConnections {
+			var items, seqnoLag uint64
+			for _, dcpStream := range connection.Streams {
+				items += dcpStream.Mutations + dcpStream.Deletions + dcpStream.Expirations
+				seqnoLag += dcpStream.SeqnoLag
+			}
+			c.insert(tx, `insert into DcpConnections(timestamp, agent, connection, name, streams, items, seqnolag, bytessent,
+			maxunackedbytes, acklag, acklagsamples, maxacklag, rollbacks) values(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				timestamp, agent, streamKey, connection.Name, len(connection.Streams), items, seqnoLag, connection.BytesSent,
+				connection.MaxUnackedBytes, connection.TotalAckLagInNanos, connection.AckLagSamples, connection.MaxAckLagInNanos,
+				connection.Rollbacks)
+		}
+		for streamKey, connection := range agentInfo.connections {
+			if connection.UserAgent == "" && len(connection.Features) == 0 {
+				continue
+			}
+			c.insert(tx, "insert into Connections(timestamp, agent, connection, useragent, features) values(?, ?, ?, ?, ?)",
+				timestamp, agent, streamKey, connection.UserAgent, strings.Join(connection.Features, ","))
+		}
+		for direction, histograms := range map[string]map[string]*pb.AgentResultsResponse_SizeHistogram{
+			"request":  agentInfo.requestSizes,
+			"response": agentInfo.responseSizes,
+		} {
+			for opcode, histogram := range histograms {
+				c.insert(tx, `insert into SizeStats(timestamp, agent, opcode, direction, count, total, max, largedocuments)
+				values(?, ?, ?, ?, ?, ?, ?, ?)`, timestamp, agent, opcode, direction, histogram.Count, histogram.TotalBytes,
+					histogram.Max, histogram.LargeDocuments)
+				for bucket, count := range histogram.Buckets {
+					if count == 0 {
+						continue
+					}
+					c.insert(tx, `insert into SizeBuckets(timestamp, agent, opcode, direction, bucket, count)
+					values(?, ?, ?, ?, ?, ?)`, timestamp, agent, opcode, direction, bucket, count)
+				}
+			}
+		}
+		for collection, latency := range agentInfo.collectionLatencies {
+			c.insert(tx, "insert into CollectionLatencies(timestamp, agent, collection, count, total, max) values(?, ?, ?, ?, ?, ?)",
+				timestamp, agent, collection, latency.Count, latency.TotalLatencyInNanos, latency.MaxLatencyInNanos)
+		}
+	}
+
 	for _, agentInfo := range agentsInfo {
 		agentInfo.results = nil
+		agentInfo.statusCounts = nil
+		agentInfo.opcodeErrors = nil
+		agentInfo.sdkLatencies = nil
+		agentInfo.connections = nil
+		agentInfo.vbucketLatencies = nil
+		agentInfo.notMyVbucketByClient = nil
+		agentInfo.configRevisions = nil
+		agentInfo.pushMessages = nil
+		agentInfo.dcpConnections = nil
+		agentInfo.subdocPaths = nil
+		agentInfo.readModifyWrites = nil
+		agentInfo.unansweredOps = nil
+		agentInfo.captureStats = nil
+		agentInfo.requestSizes = nil
+		agentInfo.responseSizes = nil
+		agentInfo.collectionLatencies = nil
 	}
 
 	tx.Commit()
@@ -330,6 +622,507 @@ func (c *Coordinator) getFullCaptureFromDb() (string, error) {
 	return string(jsonData), nil
 }
 
+func (c *Coordinator) getErrorRatesFromDb() (string, error) {
+	rows, err := c.db.Query(`select agent, opcode, sum(total), sum(errors) from OpcodeErrors group by agent, opcode;`)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	type errorRate struct {
+		Agent  string  `json:"agent"`
+		Opcode string  `json:"opcode"`
+		Total  int64   `json:"total"`
+		Errors int64   `json:"errors"`
+		Rate   float64 `json:"rate"`
+	}
+	errorRates := make([]errorRate, 0)
+	for rows.Next() {
+		var row errorRate
+		if err := rows.Scan(&row.Agent, &row.Opcode, &row.Total, &row.Errors); err != nil {
+			return "", err
+		}
+		if row.Total > 0 {
+			row.Rate = float64(row.Errors) / float64(row.Total)
+		}
+		errorRates = append(errorRates, row)
+	}
+
+	jsonData, err := json.Marshal(errorRates)
+	if err != nil {
+		return "", err
+	}
+	return string(jsonData), nil
+}
+
+func (c *Coordinator) getSdkLatenciesFromDb() (string, error) {
+	rows, err := c.db.Query(`select agent, sdk, sum(count), sum(total), max(max) from SdkLatencies group by agent, sdk;`)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	type sdkLatency struct {
+		Agent   string `json:"agent"`
+		Sdk     string `json:"sdk"`
+		Count   int64  `json:"count"`
+		Average int64  `json:"average"`
+		Max     int64  `json:"max"`
+	}
+	sdkLatencies := make([]sdkLatency, 0)
+	for rows.Next() {
+		var row sdkLatency
+		var total int64
+		if err := rows.Scan(&row.Agent, &row.Sdk, &row.Count, &total, &row.Max); err != nil {
+			return "", err
+		}
+		if row.Count > 0 {
+			row.Average = total / row.Count
+		}
+		sdkLatencies = append(sdkLatencies, row)
+	}
+
+	jsonData, err := json.Marshal(sdkLatencies)
+	if err != nil {
+		return "", err
+	}
+	return string(jsonData), nil
+}
+
+func (c *Coordinator) getConnectionsFromDb() (string, error) {
+	rows, err := c.db.Query(`select agent, connection, useragent, features from Connections group by agent, connection;`)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	type connection struct {
+		Agent      string `json:"agent"`
+		Connection string `json:"connection"`
+		UserAgent  string `json:"useragent"`
+		Features   string `json:"features"`
+	}
+	connections := make([]connection, 0)
+	for rows.Next() {
+		var row connection
+		if err := rows.Scan(&row.Agent, &row.Connection, &row.UserAgent, &row.Features); err != nil {
+			return "", err
+		}
+		connections = append(connections, row)
+	}
+
+	jsonData, err := json.Marshal(connections)
+	if err != nil {
+		return "", err
+	}
+	return string(jsonData), nil
+}
+
+//getVbucketStatsFromDb returns the busiest vbuckets and the clients which got NOT_MY_VBUCKET
+func (c *Coordinator) getVbucketStatsFromDb() (string, error) {
+	type vbucketLatency struct {
+		Vbucket int64 `json:"vbucket"`
+		Count   int64 `json:"count"`
+		Average int64 `json:"average"`
+		Max     int64 `json:"max"`
+	}
+	type notMyVbucket struct {
+		Agent  string `json:"agent"`
+		Client string `json:"client"`
+		Count  int64  `json:"count"`
+	}
+	stats := struct {
+		Hot          []vbucketLatency `json:"hot"`
+		NotMyVbucket []notMyVbucket   `json:"notMyVbucket"`
+	}{
+		Hot:          make([]vbucketLatency, 0),
+		NotMyVbucket: make([]notMyVbucket, 0),
+	}
+
+	rows, err := c.db.Query(`select vbucket, sum(count), sum(total), max(max) from VbucketLatencies
+	group by vbucket order by sum(count) desc limit 10;`)
+	if err != nil {
+		return "", err
+	}
+	for rows.Next() {
+		var row vbucketLatency
+		var total int64
+		if err := rows.Scan(&row.Vbucket, &row.Count, &total, &row.Max); err != nil {
+			rows.Close()
+			return "", err
+		}
+		if row.Count > 0 {
+			row.Average = total / row.Count
+		}
+		stats.Hot = append(stats.Hot, row)
+	}
+	rows.Close()
+
+	rows, err = c.db.Query(`select agent, client, sum(count) from NotMyVbucket group by agent, client order by sum(count) desc;`)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var row notMyVbucket
+		if err := rows.Scan(&row.Agent, &row.Client, &row.Count); err != nil {
+			return "", err
+		}
+		stats.NotMyVbucket = append(stats.NotMyVbucket, row)
+	}
+
+	jsonData, err := json.Marshal(stats)
+	if err != nil {
+		return "", err
+	}
+	return string(jsonData), nil
+}
+
+func (c *Coordinator) getConfigRevisionsFromDb() (string, error) {
+	rows, err := c.db.Query(`select min(timestamp), bucket, revepoch, rev, source, nodes, max(topologychange)
+	from ConfigRevisions group by bucket, revepoch, rev order by min(timestamp);`)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	type configRevision struct {
+		Timestamp      int64  `json:"timestamp"`
+		Bucket         string `json:"bucket"`
+		RevEpoch       int64  `json:"revEpoch"`
+		Rev            int64  `json:"rev"`
+		Source         string `json:"source"`
+		Nodes          string `json:"nodes"`
+		TopologyChange bool   `json:"topologyChange"`
+	}
+	revisions := make([]configRevision, 0)
+	for rows.Next() {
+		var row configRevision
+		if err := rows.Scan(&row.Timestamp, &row.Bucket, &row.RevEpoch, &row.Rev, &row.Source, &row.Nodes,
+			&row.TopologyChange); err != nil {
+			return "", err
+		}
+		revisions = append(revisions, row)
+	}
+
+	jsonData, err := json.Marshal(revisions)
+	if err != nil {
+		return "", err
+	}
+	return string(jsonData), nil
+}
+
+func (c *Coordinator) getPushMessagesFromDb() (string, error) {
+	rows, err := c.db.Query(`select agent, opcode, count(*), count(latency), ifnull(avg(latency), 0) from PushMessages
+	group by agent, opcode;`)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	type pushMessages struct {
+		Agent    string  `json:"agent"`
+		Opcode   string  `json:"opcode"`
+		Count    int64   `json:"count"`
+		Answered int64   `json:"answered"`
+		Average  float64 `json:"average"`
+	}
+	messages := make([]pushMessages, 0)
+	for rows.Next() {
+		var row pushMessages
+		if err := rows.Scan(&row.Agent, &row.Opcode, &row.Count, &row.Answered, &row.Average); err != nil {
+			return "", err
+		}
+		messages = append(messages, row)
+	}
+
+	jsonData, err := json.Marshal(messages)
+	if err != nil {
+		return "", err
+	}
+	return string(jsonData), nil
+}
+
+func (c *Coordinator) getDcpConnectionsFromDb() (string, error) {
+	rows, err := c.db.Query(`select agent, name, max(streams), sum(items), sum(bytessent), max(maxunackedbytes),
+	sum(acklag), sum(acklagsamples), max(maxacklag), sum(rollbacks) from DcpConnections group by agent, connection;`)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	type dcpConnection struct {
+		Agent           string `json:"agent"`
+		Name            string `json:"name"`
+		Streams         int64  `json:"streams"`
+		Items           int64  `json:"items"`
+		BytesSent       int64  `json:"bytesSent"`
+		MaxUnackedBytes int64  `json:"maxUnackedBytes"`
+		AverageAckLag   int64  `json:"averageAckLag"`
+		MaxAckLag       int64  `json:"maxAckLag"`
+		Rollbacks       int64  `json:"rollbacks"`
+	}
+	connections := make([]dcpConnection, 0)
+	for rows.Next() {
+		var row dcpConnection
+		var ackLag, ackLagSamples int64
+		if err := rows.Scan(&row.Agent, &row.Name, &row.Streams, &row.Items, &row.BytesSent, &row.MaxUnackedBytes,
+			&ackLag, &ackLagSamples, &row.MaxAckLag, &row.Rollbacks); err != nil {
+			return "", err
+		}
+		if ackLagSamples > 0 {
+			row.AverageAckLag = ackLag / ackLagSamples
+		}
+		connections = append(connections, row)
+	}
+
+	jsonData, err := json.Marshal(connections)
+	if err != nil {
+		return "", err
+	}
+	return string(jsonData), nil
+}
+
+//getSubdocStatsFromDb returns the most expensive subdoc paths and the clients which read and
+//replace whole documents
+func (c *Coordinator) getSubdocStatsFromDb() (string, error) {
+	type subdocPath struct {
+		Opcode  string `json:"opcode"`
+		Path    string `json:"path"`
+		Xattr   bool   `json:"xattr"`
+		Count   int64  `json:"count"`
+		Errors  int64  `json:"errors"`
+		Total   int64  `json:"total"`
+		Average int64  `json:"average"`
+		Max     int64  `json:"max"`
+		Bytes   int64  `json:"bytes"`
+	}
+	type readModifyWrite struct {
+		Agent  string `json:"agent"`
+		Client string `json:"client"`
+		Count  int64  `json:"count"`
+	}
+	stats := struct {
+		Paths            []subdocPath      `json:"paths"`
+		ReadModifyWrites []readModifyWrite `json:"readModifyWrites"`
+	}{
+		Paths:            make([]subdocPath, 0),
+		ReadModifyWrites: make([]readModifyWrite, 0),
+	}
+
+	rows, err := c.db.Query(`select opcode, path, xattr, sum(count), sum(errors), sum(total), max(max), sum(bytes)
+	from SubdocPaths group by opcode, path, xattr order by sum(total) desc limit 20;`)
+	if err != nil {
+		return "", err
+	}
+	for rows.Next() {
+		var row subdocPath
+		if err := rows.Scan(&row.Opcode, &row.Path, &row.Xattr, &row.Count, &row.Errors, &row.Total, &row.Max,
+			&row.Bytes); err != nil {
+			rows.Close()
+			return "", err
+		}
+		if row.Count > 0 {
+			row.Average = row.Total / row.Count
+		}
+		stats.Paths = append(stats.Paths, row)
+	}
+	rows.Close()
+
+	rows, err = c.db.Query(`select agent, client, sum(count) from ReadModifyWrites group by agent, client
+	order by sum(count) desc;`)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var row readModifyWrite
+		if err := rows.Scan(&row.Agent, &row.Client, &row.Count); err != nil {
+			return "", err
+		}
+		stats.ReadModifyWrites = append(stats.ReadModifyWrites, row)
+	}
+
+	jsonData, err := json.Marshal(stats)
+	if err != nil {
+		return "", err
+	}
+	return string(jsonData), nil
+}
+
+func (c *Coordinator) getUnansweredOpsFromDb() (string, error) {
+	rows, err := c.db.Query(`select timestamp, agent, connection, opcode, key, age, connectionclosed from UnansweredOps
+	order by timestamp desc limit 100;`)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	type unansweredOp struct {
+		Timestamp        int64  `json:"timestamp"`
+		Agent            string `json:"agent"`
+		Connection       string `json:"connection"`
+		Opcode           string `json:"opcode"`
+		Key              string `json:"key"`
+		Age              int64  `json:"age"`
+		ConnectionClosed bool   `json:"connectionClosed"`
+	}
+	unansweredOps := make([]unansweredOp, 0)
+	for rows.Next() {
+		var row unansweredOp
+		if err := rows.Scan(&row.Timestamp, &row.Agent, &row.Connection, &row.Opcode, &row.Key, &row.Age,
+			&row.ConnectionClosed); err != nil {
+			return "", err
+		}
+		unansweredOps = append(unansweredOps, row)
+	}
+
+	jsonData, err := json.Marshal(unansweredOps)
+	if err != nil {
+		return "", err
+	}
+	return string(jsonData), nil
+}
+
+//getCaptureStatsFromDb sums the packet counters of every sniffer over the stored captures, results
+//are incomplete when any of them dropped packets
+func (c *Coordinator) getCaptureStatsFromDb() (string, error) {
+	rows, err := c.db.Query(`select agent, device, sniffer, sum(received), sum(dropped), sum(ifdropped), max(error)
+	from CaptureStats group by agent, device, sniffer order by agent, device;`)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	type captureStats struct {
+		Agent     string `json:"agent"`
+		Device    string `json:"device"`
+		Sniffer   string `json:"sniffer"`
+		Received  uint64 `json:"received"`
+		Dropped   uint64 `json:"dropped"`
+		IfDropped uint64 `json:"ifDropped"`
+		Error     string `json:"error"`
+	}
+	stats := make([]captureStats, 0)
+	for rows.Next() {
+		var row captureStats
+		if err := rows.Scan(&row.Agent, &row.Device, &row.Sniffer, &row.Received, &row.Dropped, &row.IfDropped,
+			&row.Error); err != nil {
+			return "", err
+		}
+		stats = append(stats, row)
+	}
+
+	jsonData, err := json.Marshal(stats)
+	if err != nil {
+		return "", err
+	}
+	return string(jsonData), nil
+}
+
+//getSizeStatsFromDb returns the value sizes per opcode, buckets[i] counts the values smaller than
+//2^i bytes which did not fit into the bucket before
+func (c *Coordinator) getSizeStatsFromDb() (string, error) {
+	rows, err := c.db.Query(`select agent, opcode, direction, sum(count), sum(total), max(max), sum(largedocuments)
+	from SizeStats group by agent, opcode, direction order by agent, opcode, direction;`)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	type sizeStats struct {
+		Agent          string   `json:"agent"`
+		Opcode         string   `json:"opcode"`
+		Direction      string   `json:"direction"`
+		Count          int64    `json:"count"`
+		Average        int64    `json:"average"`
+		Max            int64    `json:"max"`
+		LargeDocuments int64    `json:"largeDocuments"`
+		Buckets        []uint64 `json:"buckets"`
+	}
+	stats := make([]*sizeStats, 0)
+	byKey := make(map[string]*sizeStats)
+	for rows.Next() {
+		row := &sizeStats{Buckets: make([]uint64, 0)}
+		var total int64
+		if err := rows.Scan(&row.Agent, &row.Opcode, &row.Direction, &row.Count, &total, &row.Max,
+			&row.LargeDocuments); err != nil {
+			return "", err
+		}
+		if row.Count > 0 {
+			row.Average = total / row.Count
+		}
+		stats = append(stats, row)
+		byKey[row.Agent+"/"+row.Opcode+"/"+row.Direction] = row
+	}
+
+	bucketRows, err := c.db.Query(`select agent, opcode, direction, bucket, sum(count) from SizeBuckets
+	group by agent, opcode, direction, bucket;`)
+	if err != nil {
+		return "", err
+	}
+	defer bucketRows.Close()
+	for bucketRows.Next() {
+		var agent, opcode, direction string
+		var bucket int
+		var count uint64
+		if err := bucketRows.Scan(&agent, &opcode, &direction, &bucket, &count); err != nil {
+			return "", err
+		}
+		row := byKey[agent+"/"+opcode+"/"+direction]
+		if row == nil {
+			continue
+		}
+		for len(row.Buckets) <= bucket {
+			row.Buckets = append(row.Buckets, 0)
+		}
+		row.Buckets[bucket] = count
+	}
+
+	jsonData, err := json.Marshal(stats)
+	if err != nil {
+		return "", err
+	}
+	return string(jsonData), nil
+}
+
+//getCollectionLatenciesFromDb returns the latency per bucket and collection, ops on connections
+//which did not negotiate collections are not included
+func (c *Coordinator) getCollectionLatenciesFromDb() (string, error) {
+	rows, err := c.db.Query(`select agent, collection, sum(count), sum(total), max(max) from CollectionLatencies
+	group by agent, collection;`)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	type collectionLatency struct {
+		Agent      string `json:"agent"`
+		Collection string `json:"collection"`
+		Count      int64  `json:"count"`
+		Average    int64  `json:"average"`
+		Max        int64  `json:"max"`
+	}
+	collectionLatencies := make([]collectionLatency, 0)
+	for rows.Next() {
+		var row collectionLatency
+		var total int64
+		if err := rows.Scan(&row.Agent, &row.Collection, &row.Count, &total, &row.Max); err != nil {
+			return "", err
+		}
+		if row.Count > 0 {
+			row.Average = total / row.Count
+		}
+		collectionLatencies = append(collectionLatencies, row)
+	}
+
+	jsonData, err := json.Marshal(collectionLatencies)
+	if err != nil {
+		return "", err
+	}
+	return string(jsonData), nil
+}
+
 func (c *Coordinator) getMaxLatency() int64 {
 	return c.histogram.Max()
 }
@@ -340,7 +1133,68 @@ func (c *Coordinator) getResults(wg *sync.WaitGroup, agentInfo *AgentInfo) {
 		c.shutdown()
 	} else {
 		c.logger.Info("Got %v capture results from %v", len(response.CaptureMap), agentInfo.hostname)
+		if response.ReassemblyGaps > 0 {
+			c.logger.Info("Capture on %v has %v reassembly gaps, %v bytes were lost", agentInfo.hostname,
+				response.ReassemblyGaps, response.SkippedBytes)
+		}
+		for streamKey, connection := range response.Connections {
+			for kind, count := range connection.ParseErrors {
+				c.logger.Info("Connection %v on %v had %v %v parse errors, resynced %v times", streamKey,
+					agentInfo.hostname, count, kind, connection.Resyncs)
+			}
+		}
+		for opcode, sizes := range response.ResponseSizes {
+			if sizes.LargeDocuments > 0 {
+				c.logger.Info("%v returned %v documents larger than the threshold on %v, largest was %v bytes",
+					opcode, sizes.LargeDocuments, agentInfo.hostname, sizes.Max)
+			}
+		}
 		agentInfo.results = response.CaptureMap
+		agentInfo.statusCounts = response.StatusCounts
+		agentInfo.opcodeErrors = response.OpcodeErrors
+		agentInfo.sdkLatencies = response.SdkLatencies
+		agentInfo.connections = response.Connections
+		agentInfo.vbucketLatencies = response.VbucketLatencies
+		agentInfo.notMyVbucketByClient = response.NotMyVbucketByClient
+		agentInfo.configRevisions = response.ConfigRevisions
+		agentInfo.pushMessages = response.PushMessages
+		agentInfo.dcpConnections = response.DcpConnections
+		agentInfo.subdocPaths = response.SubdocPaths
+		agentInfo.readModifyWrites = response.ReadModifyWritesByClient
+		agentInfo.unansweredOps = response.UnansweredOps
+		agentInfo.captureStats = response.CaptureStats
+		agentInfo.requestSizes = response.RequestSizes
+		agentInfo.responseSizes = response.ResponseSizes
+		agentInfo.collectionLatencies = response.CollectionLatencies
+		for _, stats := range response.CaptureStats {
+			if stats.Error != "" {
+				c.logger.Info("No packet statistics for %v on %v: %v", stats.Device, agentInfo.hostname, stats.Error)
+			} else if stats.Dropped > 0 || stats.IfDropped > 0 {
+				c.logger.Info("Capture on %v of %v is incomplete, %v of %v packets were dropped by the kernel and %v by the interface",
+					stats.Device, agentInfo.hostname, stats.Dropped, stats.Received, stats.IfDropped)
+			}
+		}
+		var unanswered, likelyTimeouts, reordered, unmatched uint64
+		for _, connection := range response.Connections {
+			unanswered += connection.Unanswered
+			likelyTimeouts += connection.LikelyTimeouts
+			reordered += connection.ReorderedResponses
+			unmatched += connection.UnmatchedResponses
+		}
+		if unanswered > 0 {
+			c.logger.Info("%v ops on %v were not answered, %v of them on connections which were closed",
+				unanswered, agentInfo.hostname, likelyTimeouts)
+		}
+		if reordered > 0 || unmatched > 0 {
+			c.logger.Info("%v responses on %v were answered out of order, %v could not be matched with a request",
+				reordered, agentInfo.hostname, unmatched)
+		}
+		for _, revision := range response.ConfigRevisions {
+			if revision.TopologyChange {
+				c.logger.Info("Topology of %v changed at revision %v on %v, nodes are %v", revision.Bucket,
+					revision.Rev, agentInfo.hostname, revision.Nodes)
+			}
+		}
 	}
 	wg.Done()
 }
@@ -44,7 +44,7 @@ func main() {
 	coordinator := &Coordinator{
 		config:     &Config{},
 		agentsInfo: make(map[string]*AgentInfo),
-		histogram:  hdrhistogram.New(1, 5*1000*1000, 3), //max histogram value for latency 5 secs
+		histogram:  hdrhistogram.New(1, 5*1000*1000*1000, 3), //max histogram value for latency 5 secs in nanos
 		logger:     &logger.Logger{},
 	}
 	loadConfig(fmt.Sprint("./", *configFile), coordinator.config)
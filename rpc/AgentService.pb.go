@@ -13,6 +13,10 @@ It has these top-level messages:
 	CoordinatorGoodByeRequest
 	AgentGoodByeResponse
 	CoordinatorResultsRequest
+	ListRecordingsRequest
+	ListRecordingsResponse
+	DownloadRecordingRequest
+	DownloadRecordingResponse
 	AgentResultsResponse
 */
 package rpc
@@ -93,72 +97,1344 @@ func (m *CoordinatorResultsRequest) String() string            { return proto.Co
 func (*CoordinatorResultsRequest) ProtoMessage()               {}
 func (*CoordinatorResultsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
 
+type ListRecordingsRequest struct {
+}
+
+func (m *ListRecordingsRequest) Reset()                    { *m = ListRecordingsRequest{} }
+func (m *ListRecordingsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListRecordingsRequest) ProtoMessage()               {}
+func (*ListRecordingsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+type ListRecordingsResponse struct {
+	Status     string                              `protobuf:"bytes,1,opt,name=status" json:"status,omitempty"`
+	Recordings []*ListRecordingsResponse_Recording `protobuf:"bytes,2,rep,name=recordings" json:"recordings,omitempty"`
+}
+
+func (m *ListRecordingsResponse) Reset()                    { *m = ListRecordingsResponse{} }
+func (m *ListRecordingsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListRecordingsResponse) ProtoMessage()               {}
+func (*ListRecordingsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *ListRecordingsResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *ListRecordingsResponse) GetRecordings() []*ListRecordingsResponse_Recording {
+	if m != nil {
+		return m.Recordings
+	}
+	return nil
+}
+
+type ListRecordingsResponse_Recording struct {
+	Name               string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Size               uint64 `protobuf:"varint,2,opt,name=size" json:"size,omitempty"`
+	FirstPacketInNanos int64  `protobuf:"varint,3,opt,name=firstPacketInNanos" json:"firstPacketInNanos,omitempty"`
+	LastPacketInNanos  int64  `protobuf:"varint,4,opt,name=lastPacketInNanos" json:"lastPacketInNanos,omitempty"`
+	Preserved          bool   `protobuf:"varint,5,opt,name=preserved" json:"preserved,omitempty"`
+	Active             bool   `protobuf:"varint,6,opt,name=active" json:"active,omitempty"`
+}
+
+func (m *ListRecordingsResponse_Recording) Reset()         { *m = ListRecordingsResponse_Recording{} }
+func (m *ListRecordingsResponse_Recording) String() string { return proto.CompactTextString(m) }
+func (*ListRecordingsResponse_Recording) ProtoMessage()    {}
+func (*ListRecordingsResponse_Recording) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{6, 0}
+}
+
+func (m *ListRecordingsResponse_Recording) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ListRecordingsResponse_Recording) GetSize() uint64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *ListRecordingsResponse_Recording) GetFirstPacketInNanos() int64 {
+	if m != nil {
+		return m.FirstPacketInNanos
+	}
+	return 0
+}
+
+func (m *ListRecordingsResponse_Recording) GetLastPacketInNanos() int64 {
+	if m != nil {
+		return m.LastPacketInNanos
+	}
+	return 0
+}
+
+func (m *ListRecordingsResponse_Recording) GetPreserved() bool {
+	if m != nil {
+		return m.Preserved
+	}
+	return false
+}
+
+func (m *ListRecordingsResponse_Recording) GetActive() bool {
+	if m != nil {
+		return m.Active
+	}
+	return false
+}
+
+type DownloadRecordingRequest struct {
+	Name   string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Offset uint64 `protobuf:"varint,2,opt,name=offset" json:"offset,omitempty"`
+	Length uint32 `protobuf:"varint,3,opt,name=length" json:"length,omitempty"`
+}
+
+func (m *DownloadRecordingRequest) Reset()                    { *m = DownloadRecordingRequest{} }
+func (m *DownloadRecordingRequest) String() string            { return proto.CompactTextString(m) }
+func (*DownloadRecordingRequest) ProtoMessage()               {}
+func (*DownloadRecordingRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *DownloadRecordingRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *DownloadRecordingRequest) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *DownloadRecordingRequest) GetLength() uint32 {
+	if m != nil {
+		return m.Length
+	}
+	return 0
+}
+
+type DownloadRecordingResponse struct {
+	Status string `protobuf:"bytes,1,opt,name=status" json:"status,omitempty"`
+	Data   []byte `protobuf:"bytes,2,opt,name=data" json:"data,omitempty"`
+	Eof    bool   `protobuf:"varint,3,opt,name=eof" json:"eof,omitempty"`
+}
+
+func (m *DownloadRecordingResponse) Reset()                    { *m = DownloadRecordingResponse{} }
+func (m *DownloadRecordingResponse) String() string            { return proto.CompactTextString(m) }
+func (*DownloadRecordingResponse) ProtoMessage()               {}
+func (*DownloadRecordingResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *DownloadRecordingResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *DownloadRecordingResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *DownloadRecordingResponse) GetEof() bool {
+	if m != nil {
+		return m.Eof
+	}
+	return false
+}
+
 type AgentResultsResponse struct {
-	Status     string                                       `protobuf:"bytes,1,opt,name=status" json:"status,omitempty"`
-	CaptureMap map[string]*AgentResultsResponse_CaptureInfo `protobuf:"bytes,2,rep,name=captureMap" json:"captureMap,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Status                   string                                          `protobuf:"bytes,1,opt,name=status" json:"status,omitempty"`
+	CaptureMap               map[string]*AgentResultsResponse_CaptureInfo    `protobuf:"bytes,2,rep,name=captureMap" json:"captureMap,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	StatusCounts             map[string]uint64                               `protobuf:"bytes,3,rep,name=statusCounts" json:"statusCounts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	OpcodeErrors             map[string]*AgentResultsResponse_OpcodeErrors   `protobuf:"bytes,4,rep,name=opcodeErrors" json:"opcodeErrors,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ReassemblyGaps           uint64                                          `protobuf:"varint,5,opt,name=reassemblyGaps" json:"reassemblyGaps,omitempty"`
+	SkippedBytes             uint64                                          `protobuf:"varint,6,opt,name=skippedBytes" json:"skippedBytes,omitempty"`
+	Connections              map[string]*AgentResultsResponse_ConnectionInfo `protobuf:"bytes,7,rep,name=connections" json:"connections,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	RequestSizes             map[string]*AgentResultsResponse_SizeHistogram  `protobuf:"bytes,8,rep,name=requestSizes" json:"requestSizes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ResponseSizes            map[string]*AgentResultsResponse_SizeHistogram  `protobuf:"bytes,9,rep,name=responseSizes" json:"responseSizes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	CollectionLatencies      map[string]*AgentResultsResponse_LatencySummary `protobuf:"bytes,10,rep,name=collectionLatencies" json:"collectionLatencies,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	SdkLatencies             map[string]*AgentResultsResponse_LatencySummary `protobuf:"bytes,11,rep,name=sdkLatencies" json:"sdkLatencies,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	VbucketLatencies         map[string]*AgentResultsResponse_LatencySummary `protobuf:"bytes,12,rep,name=vbucketLatencies" json:"vbucketLatencies,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	NotMyVbucketByClient     map[string]uint64                               `protobuf:"bytes,13,rep,name=notMyVbucketByClient" json:"notMyVbucketByClient,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	ConfigRevisions          []*AgentResultsResponse_ConfigRevision          `protobuf:"bytes,14,rep,name=configRevisions" json:"configRevisions,omitempty"`
+	PushMessages             []*AgentResultsResponse_PushMessage             `protobuf:"bytes,15,rep,name=pushMessages" json:"pushMessages,omitempty"`
+	DcpConnections           map[string]*AgentResultsResponse_DcpConnection  `protobuf:"bytes,16,rep,name=dcpConnections" json:"dcpConnections,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	SubdocPaths              []*AgentResultsResponse_SubdocPath              `protobuf:"bytes,17,rep,name=subdocPaths" json:"subdocPaths,omitempty"`
+	ReadModifyWritesByClient map[string]uint64                               `protobuf:"bytes,18,rep,name=readModifyWritesByClient" json:"readModifyWritesByClient,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	UnansweredOps            []*AgentResultsResponse_UnansweredOp            `protobuf:"bytes,19,rep,name=unansweredOps" json:"unansweredOps,omitempty"`
+	CaptureStats             []*AgentResultsResponse_CaptureStats            `protobuf:"bytes,20,rep,name=captureStats" json:"captureStats,omitempty"`
+}
+
+func (m *AgentResultsResponse) Reset()                    { *m = AgentResultsResponse{} }
+func (m *AgentResultsResponse) String() string            { return proto.CompactTextString(m) }
+func (*AgentResultsResponse) ProtoMessage()               {}
+func (*AgentResultsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *AgentResultsResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *AgentResultsResponse) GetCaptureMap() map[string]*AgentResultsResponse_CaptureInfo {
+	if m != nil {
+		return m.CaptureMap
+	}
+	return nil
+}
+
+func (m *AgentResultsResponse) GetStatusCounts() map[string]uint64 {
+	if m != nil {
+		return m.StatusCounts
+	}
+	return nil
+}
+
+func (m *AgentResultsResponse) GetOpcodeErrors() map[string]*AgentResultsResponse_OpcodeErrors {
+	if m != nil {
+		return m.OpcodeErrors
+	}
+	return nil
+}
+
+func (m *AgentResultsResponse) GetReassemblyGaps() uint64 {
+	if m != nil {
+		return m.ReassemblyGaps
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse) GetSkippedBytes() uint64 {
+	if m != nil {
+		return m.SkippedBytes
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse) GetConnections() map[string]*AgentResultsResponse_ConnectionInfo {
+	if m != nil {
+		return m.Connections
+	}
+	return nil
+}
+
+func (m *AgentResultsResponse) GetRequestSizes() map[string]*AgentResultsResponse_SizeHistogram {
+	if m != nil {
+		return m.RequestSizes
+	}
+	return nil
+}
+
+func (m *AgentResultsResponse) GetResponseSizes() map[string]*AgentResultsResponse_SizeHistogram {
+	if m != nil {
+		return m.ResponseSizes
+	}
+	return nil
+}
+
+func (m *AgentResultsResponse) GetCollectionLatencies() map[string]*AgentResultsResponse_LatencySummary {
+	if m != nil {
+		return m.CollectionLatencies
+	}
+	return nil
+}
+
+func (m *AgentResultsResponse) GetSdkLatencies() map[string]*AgentResultsResponse_LatencySummary {
+	if m != nil {
+		return m.SdkLatencies
+	}
+	return nil
+}
+
+func (m *AgentResultsResponse) GetVbucketLatencies() map[string]*AgentResultsResponse_LatencySummary {
+	if m != nil {
+		return m.VbucketLatencies
+	}
+	return nil
+}
+
+func (m *AgentResultsResponse) GetNotMyVbucketByClient() map[string]uint64 {
+	if m != nil {
+		return m.NotMyVbucketByClient
+	}
+	return nil
+}
+
+func (m *AgentResultsResponse) GetConfigRevisions() []*AgentResultsResponse_ConfigRevision {
+	if m != nil {
+		return m.ConfigRevisions
+	}
+	return nil
+}
+
+func (m *AgentResultsResponse) GetPushMessages() []*AgentResultsResponse_PushMessage {
+	if m != nil {
+		return m.PushMessages
+	}
+	return nil
+}
+
+func (m *AgentResultsResponse) GetDcpConnections() map[string]*AgentResultsResponse_DcpConnection {
+	if m != nil {
+		return m.DcpConnections
+	}
+	return nil
+}
+
+func (m *AgentResultsResponse) GetSubdocPaths() []*AgentResultsResponse_SubdocPath {
+	if m != nil {
+		return m.SubdocPaths
+	}
+	return nil
+}
+
+func (m *AgentResultsResponse) GetReadModifyWritesByClient() map[string]uint64 {
+	if m != nil {
+		return m.ReadModifyWritesByClient
+	}
+	return nil
+}
+
+func (m *AgentResultsResponse) GetUnansweredOps() []*AgentResultsResponse_UnansweredOp {
+	if m != nil {
+		return m.UnansweredOps
+	}
+	return nil
+}
+
+func (m *AgentResultsResponse) GetCaptureStats() []*AgentResultsResponse_CaptureStats {
+	if m != nil {
+		return m.CaptureStats
+	}
+	return nil
+}
+
+type AgentResultsResponse_CaptureInfo struct {
+	Oplatency             string `protobuf:"bytes,1,opt,name=oplatency" json:"oplatency,omitempty"`
+	Key                   string `protobuf:"bytes,2,opt,name=key" json:"key,omitempty"`
+	Opaque                string `protobuf:"bytes,3,opt,name=opaque" json:"opaque,omitempty"`
+	Opcode                string `protobuf:"bytes,4,opt,name=opcode" json:"opcode,omitempty"`
+	Status                string `protobuf:"bytes,5,opt,name=status" json:"status,omitempty"`
+	Serverduration        string `protobuf:"bytes,6,opt,name=serverduration" json:"serverduration,omitempty"`
+	Durability            string `protobuf:"bytes,7,opt,name=durability" json:"durability,omitempty"`
+	ImpersonatedUser      string `protobuf:"bytes,8,opt,name=impersonatedUser" json:"impersonatedUser,omitempty"`
+	StreamId              uint32 `protobuf:"varint,9,opt,name=streamId" json:"streamId,omitempty"`
+	LatencyInNanos        int64  `protobuf:"varint,10,opt,name=latencyInNanos" json:"latencyInNanos,omitempty"`
+	ServerDurationInNanos int64  `protobuf:"varint,11,opt,name=serverDurationInNanos" json:"serverDurationInNanos,omitempty"`
+	TimestampInNanos      int64  `protobuf:"varint,12,opt,name=timestampInNanos" json:"timestampInNanos,omitempty"`
+	RequestCas            uint64 `protobuf:"varint,13,opt,name=requestCas" json:"requestCas,omitempty"`
+	ResponseCas           uint64 `protobuf:"varint,14,opt,name=responseCas" json:"responseCas,omitempty"`
+	RequestDatatype       string `protobuf:"bytes,15,opt,name=requestDatatype" json:"requestDatatype,omitempty"`
+	ResponseDatatype      string `protobuf:"bytes,16,opt,name=responseDatatype" json:"responseDatatype,omitempty"`
+	RequestSize           uint32 `protobuf:"varint,17,opt,name=requestSize" json:"requestSize,omitempty"`
+	ResponseSize          uint32 `protobuf:"varint,18,opt,name=responseSize" json:"responseSize,omitempty"`
+	LargeDocument         bool   `protobuf:"varint,19,opt,name=largeDocument" json:"largeDocument,omitempty"`
+	Bucket                string `protobuf:"bytes,20,opt,name=bucket" json:"bucket,omitempty"`
+	User                  string `protobuf:"bytes,21,opt,name=user" json:"user,omitempty"`
+	CollectionId          uint32 `protobuf:"varint,22,opt,name=collectionId" json:"collectionId,omitempty"`
+	Collection            string `protobuf:"bytes,23,opt,name=collection" json:"collection,omitempty"`
+	Sdk                   string `protobuf:"bytes,24,opt,name=sdk" json:"sdk,omitempty"`
+	Vbucket               uint32 `protobuf:"varint,25,opt,name=vbucket" json:"vbucket,omitempty"`
+	Inferred              bool   `protobuf:"varint,26,opt,name=inferred" json:"inferred,omitempty"`
+}
+
+func (m *AgentResultsResponse_CaptureInfo) Reset()         { *m = AgentResultsResponse_CaptureInfo{} }
+func (m *AgentResultsResponse_CaptureInfo) String() string { return proto.CompactTextString(m) }
+func (*AgentResultsResponse_CaptureInfo) ProtoMessage()    {}
+func (*AgentResultsResponse_CaptureInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{9, 0}
+}
+
+func (m *AgentResultsResponse_CaptureInfo) GetOplatency() string {
+	if m != nil {
+		return m.Oplatency
+	}
+	return ""
+}
+
+func (m *AgentResultsResponse_CaptureInfo) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *AgentResultsResponse_CaptureInfo) GetOpaque() string {
+	if m != nil {
+		return m.Opaque
+	}
+	return ""
+}
+
+func (m *AgentResultsResponse_CaptureInfo) GetOpcode() string {
+	if m != nil {
+		return m.Opcode
+	}
+	return ""
+}
+
+func (m *AgentResultsResponse_CaptureInfo) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *AgentResultsResponse_CaptureInfo) GetServerduration() string {
+	if m != nil {
+		return m.Serverduration
+	}
+	return ""
+}
+
+func (m *AgentResultsResponse_CaptureInfo) GetDurability() string {
+	if m != nil {
+		return m.Durability
+	}
+	return ""
+}
+
+func (m *AgentResultsResponse_CaptureInfo) GetImpersonatedUser() string {
+	if m != nil {
+		return m.ImpersonatedUser
+	}
+	return ""
+}
+
+func (m *AgentResultsResponse_CaptureInfo) GetStreamId() uint32 {
+	if m != nil {
+		return m.StreamId
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_CaptureInfo) GetLatencyInNanos() int64 {
+	if m != nil {
+		return m.LatencyInNanos
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_CaptureInfo) GetServerDurationInNanos() int64 {
+	if m != nil {
+		return m.ServerDurationInNanos
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_CaptureInfo) GetTimestampInNanos() int64 {
+	if m != nil {
+		return m.TimestampInNanos
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_CaptureInfo) GetRequestCas() uint64 {
+	if m != nil {
+		return m.RequestCas
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_CaptureInfo) GetResponseCas() uint64 {
+	if m != nil {
+		return m.ResponseCas
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_CaptureInfo) GetRequestDatatype() string {
+	if m != nil {
+		return m.RequestDatatype
+	}
+	return ""
+}
+
+func (m *AgentResultsResponse_CaptureInfo) GetResponseDatatype() string {
+	if m != nil {
+		return m.ResponseDatatype
+	}
+	return ""
+}
+
+func (m *AgentResultsResponse_CaptureInfo) GetRequestSize() uint32 {
+	if m != nil {
+		return m.RequestSize
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_CaptureInfo) GetResponseSize() uint32 {
+	if m != nil {
+		return m.ResponseSize
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_CaptureInfo) GetLargeDocument() bool {
+	if m != nil {
+		return m.LargeDocument
+	}
+	return false
+}
+
+func (m *AgentResultsResponse_CaptureInfo) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+func (m *AgentResultsResponse_CaptureInfo) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *AgentResultsResponse_CaptureInfo) GetCollectionId() uint32 {
+	if m != nil {
+		return m.CollectionId
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_CaptureInfo) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+func (m *AgentResultsResponse_CaptureInfo) GetSdk() string {
+	if m != nil {
+		return m.Sdk
+	}
+	return ""
+}
+
+func (m *AgentResultsResponse_CaptureInfo) GetVbucket() uint32 {
+	if m != nil {
+		return m.Vbucket
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_CaptureInfo) GetInferred() bool {
+	if m != nil {
+		return m.Inferred
+	}
+	return false
+}
+
+type AgentResultsResponse_OpcodeErrors struct {
+	Total  uint64 `protobuf:"varint,1,opt,name=total" json:"total,omitempty"`
+	Errors uint64 `protobuf:"varint,2,opt,name=errors" json:"errors,omitempty"`
+}
+
+func (m *AgentResultsResponse_OpcodeErrors) Reset()         { *m = AgentResultsResponse_OpcodeErrors{} }
+func (m *AgentResultsResponse_OpcodeErrors) String() string { return proto.CompactTextString(m) }
+func (*AgentResultsResponse_OpcodeErrors) ProtoMessage()    {}
+func (*AgentResultsResponse_OpcodeErrors) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{9, 1}
+}
+
+func (m *AgentResultsResponse_OpcodeErrors) GetTotal() uint64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_OpcodeErrors) GetErrors() uint64 {
+	if m != nil {
+		return m.Errors
+	}
+	return 0
+}
+
+type AgentResultsResponse_ConnectionInfo struct {
+	ParseErrors        map[string]uint64 `protobuf:"bytes,1,rep,name=parseErrors" json:"parseErrors,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	Resyncs            uint64            `protobuf:"varint,2,opt,name=resyncs" json:"resyncs,omitempty"`
+	ReassemblyGaps     uint64            `protobuf:"varint,3,opt,name=reassemblyGaps" json:"reassemblyGaps,omitempty"`
+	SkippedBytes       uint64            `protobuf:"varint,4,opt,name=skippedBytes" json:"skippedBytes,omitempty"`
+	Bucket             string            `protobuf:"bytes,5,opt,name=bucket" json:"bucket,omitempty"`
+	User               string            `protobuf:"bytes,6,opt,name=user" json:"user,omitempty"`
+	UserAgent          string            `protobuf:"bytes,7,opt,name=userAgent" json:"userAgent,omitempty"`
+	ClientConnectionId string            `protobuf:"bytes,8,opt,name=clientConnectionId" json:"clientConnectionId,omitempty"`
+	Features           []string          `protobuf:"bytes,9,rep,name=features" json:"features,omitempty"`
+	Client             string            `protobuf:"bytes,10,opt,name=client" json:"client,omitempty"`
+	NotMyVbucket       uint64            `protobuf:"varint,11,opt,name=notMyVbucket" json:"notMyVbucket,omitempty"`
+	ReadModifyWrites   uint64            `protobuf:"varint,12,opt,name=readModifyWrites" json:"readModifyWrites,omitempty"`
+	Unanswered         uint64            `protobuf:"varint,13,opt,name=unanswered" json:"unanswered,omitempty"`
+	LikelyTimeouts     uint64            `protobuf:"varint,14,opt,name=likelyTimeouts" json:"likelyTimeouts,omitempty"`
+	ReorderedResponses uint64            `protobuf:"varint,15,opt,name=reorderedResponses" json:"reorderedResponses,omitempty"`
+	UnmatchedResponses uint64            `protobuf:"varint,16,opt,name=unmatchedResponses" json:"unmatchedResponses,omitempty"`
+}
+
+func (m *AgentResultsResponse_ConnectionInfo) Reset()         { *m = AgentResultsResponse_ConnectionInfo{} }
+func (m *AgentResultsResponse_ConnectionInfo) String() string { return proto.CompactTextString(m) }
+func (*AgentResultsResponse_ConnectionInfo) ProtoMessage()    {}
+func (*AgentResultsResponse_ConnectionInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{9, 2}
+}
+
+func (m *AgentResultsResponse_ConnectionInfo) GetParseErrors() map[string]uint64 {
+	if m != nil {
+		return m.ParseErrors
+	}
+	return nil
+}
+
+func (m *AgentResultsResponse_ConnectionInfo) GetResyncs() uint64 {
+	if m != nil {
+		return m.Resyncs
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_ConnectionInfo) GetReassemblyGaps() uint64 {
+	if m != nil {
+		return m.ReassemblyGaps
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_ConnectionInfo) GetSkippedBytes() uint64 {
+	if m != nil {
+		return m.SkippedBytes
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_ConnectionInfo) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+func (m *AgentResultsResponse_ConnectionInfo) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *AgentResultsResponse_ConnectionInfo) GetUserAgent() string {
+	if m != nil {
+		return m.UserAgent
+	}
+	return ""
+}
+
+func (m *AgentResultsResponse_ConnectionInfo) GetClientConnectionId() string {
+	if m != nil {
+		return m.ClientConnectionId
+	}
+	return ""
+}
+
+func (m *AgentResultsResponse_ConnectionInfo) GetFeatures() []string {
+	if m != nil {
+		return m.Features
+	}
+	return nil
+}
+
+func (m *AgentResultsResponse_ConnectionInfo) GetClient() string {
+	if m != nil {
+		return m.Client
+	}
+	return ""
+}
+
+func (m *AgentResultsResponse_ConnectionInfo) GetNotMyVbucket() uint64 {
+	if m != nil {
+		return m.NotMyVbucket
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_ConnectionInfo) GetReadModifyWrites() uint64 {
+	if m != nil {
+		return m.ReadModifyWrites
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_ConnectionInfo) GetUnanswered() uint64 {
+	if m != nil {
+		return m.Unanswered
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_ConnectionInfo) GetLikelyTimeouts() uint64 {
+	if m != nil {
+		return m.LikelyTimeouts
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_ConnectionInfo) GetReorderedResponses() uint64 {
+	if m != nil {
+		return m.ReorderedResponses
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_ConnectionInfo) GetUnmatchedResponses() uint64 {
+	if m != nil {
+		return m.UnmatchedResponses
+	}
+	return 0
+}
+
+type AgentResultsResponse_LatencySummary struct {
+	Count               uint64 `protobuf:"varint,1,opt,name=count" json:"count,omitempty"`
+	TotalLatencyInNanos int64  `protobuf:"varint,2,opt,name=totalLatencyInNanos" json:"totalLatencyInNanos,omitempty"`
+	MaxLatencyInNanos   int64  `protobuf:"varint,3,opt,name=maxLatencyInNanos" json:"maxLatencyInNanos,omitempty"`
+}
+
+func (m *AgentResultsResponse_LatencySummary) Reset()         { *m = AgentResultsResponse_LatencySummary{} }
+func (m *AgentResultsResponse_LatencySummary) String() string { return proto.CompactTextString(m) }
+func (*AgentResultsResponse_LatencySummary) ProtoMessage()    {}
+func (*AgentResultsResponse_LatencySummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{9, 3}
+}
+
+func (m *AgentResultsResponse_LatencySummary) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_LatencySummary) GetTotalLatencyInNanos() int64 {
+	if m != nil {
+		return m.TotalLatencyInNanos
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_LatencySummary) GetMaxLatencyInNanos() int64 {
+	if m != nil {
+		return m.MaxLatencyInNanos
+	}
+	return 0
+}
+
+type AgentResultsResponse_ConfigRevision struct {
+	TimestampInNanos int64    `protobuf:"varint,1,opt,name=timestampInNanos" json:"timestampInNanos,omitempty"`
+	Bucket           string   `protobuf:"bytes,2,opt,name=bucket" json:"bucket,omitempty"`
+	RevEpoch         int64    `protobuf:"varint,3,opt,name=revEpoch" json:"revEpoch,omitempty"`
+	Rev              int64    `protobuf:"varint,4,opt,name=rev" json:"rev,omitempty"`
+	Source           string   `protobuf:"bytes,5,opt,name=source" json:"source,omitempty"`
+	Nodes            []string `protobuf:"bytes,6,rep,name=nodes" json:"nodes,omitempty"`
+	TopologyChange   bool     `protobuf:"varint,7,opt,name=topologyChange" json:"topologyChange,omitempty"`
+}
+
+func (m *AgentResultsResponse_ConfigRevision) Reset()         { *m = AgentResultsResponse_ConfigRevision{} }
+func (m *AgentResultsResponse_ConfigRevision) String() string { return proto.CompactTextString(m) }
+func (*AgentResultsResponse_ConfigRevision) ProtoMessage()    {}
+func (*AgentResultsResponse_ConfigRevision) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{9, 4}
+}
+
+func (m *AgentResultsResponse_ConfigRevision) GetTimestampInNanos() int64 {
+	if m != nil {
+		return m.TimestampInNanos
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_ConfigRevision) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+func (m *AgentResultsResponse_ConfigRevision) GetRevEpoch() int64 {
+	if m != nil {
+		return m.RevEpoch
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_ConfigRevision) GetRev() int64 {
+	if m != nil {
+		return m.Rev
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_ConfigRevision) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *AgentResultsResponse_ConfigRevision) GetNodes() []string {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+func (m *AgentResultsResponse_ConfigRevision) GetTopologyChange() bool {
+	if m != nil {
+		return m.TopologyChange
+	}
+	return false
+}
+
+type AgentResultsResponse_PushMessage struct {
+	Opcode           string `protobuf:"bytes,1,opt,name=opcode" json:"opcode,omitempty"`
+	TimestampInNanos int64  `protobuf:"varint,2,opt,name=timestampInNanos" json:"timestampInNanos,omitempty"`
+	LatencyInNanos   int64  `protobuf:"varint,3,opt,name=latencyInNanos" json:"latencyInNanos,omitempty"`
+	Status           string `protobuf:"bytes,4,opt,name=status" json:"status,omitempty"`
+	Key              string `protobuf:"bytes,5,opt,name=key" json:"key,omitempty"`
+	Answered         bool   `protobuf:"varint,6,opt,name=answered" json:"answered,omitempty"`
+}
+
+func (m *AgentResultsResponse_PushMessage) Reset()         { *m = AgentResultsResponse_PushMessage{} }
+func (m *AgentResultsResponse_PushMessage) String() string { return proto.CompactTextString(m) }
+func (*AgentResultsResponse_PushMessage) ProtoMessage()    {}
+func (*AgentResultsResponse_PushMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{9, 5}
+}
+
+func (m *AgentResultsResponse_PushMessage) GetOpcode() string {
+	if m != nil {
+		return m.Opcode
+	}
+	return ""
+}
+
+func (m *AgentResultsResponse_PushMessage) GetTimestampInNanos() int64 {
+	if m != nil {
+		return m.TimestampInNanos
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_PushMessage) GetLatencyInNanos() int64 {
+	if m != nil {
+		return m.LatencyInNanos
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_PushMessage) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *AgentResultsResponse_PushMessage) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *AgentResultsResponse_PushMessage) GetAnswered() bool {
+	if m != nil {
+		return m.Answered
+	}
+	return false
+}
+
+type AgentResultsResponse_DcpStream struct {
+	Vbucket           uint32 `protobuf:"varint,1,opt,name=vbucket" json:"vbucket,omitempty"`
+	StreamId          uint32 `protobuf:"varint,2,opt,name=streamId" json:"streamId,omitempty"`
+	StartSeqno        uint64 `protobuf:"varint,3,opt,name=startSeqno" json:"startSeqno,omitempty"`
+	EndSeqno          uint64 `protobuf:"varint,4,opt,name=endSeqno" json:"endSeqno,omitempty"`
+	LastSeqno         uint64 `protobuf:"varint,5,opt,name=lastSeqno" json:"lastSeqno,omitempty"`
+	Mutations         uint64 `protobuf:"varint,6,opt,name=mutations" json:"mutations,omitempty"`
+	Deletions         uint64 `protobuf:"varint,7,opt,name=deletions" json:"deletions,omitempty"`
+	Expirations       uint64 `protobuf:"varint,8,opt,name=expirations" json:"expirations,omitempty"`
+	Snapshots         uint64 `protobuf:"varint,9,opt,name=snapshots" json:"snapshots,omitempty"`
+	TotalSnapshotSize uint64 `protobuf:"varint,10,opt,name=totalSnapshotSize" json:"totalSnapshotSize,omitempty"`
+	MaxSnapshotSize   uint64 `protobuf:"varint,11,opt,name=maxSnapshotSize" json:"maxSnapshotSize,omitempty"`
+	SeqnoLag          uint64 `protobuf:"varint,12,opt,name=seqnoLag" json:"seqnoLag,omitempty"`
+	Ended             bool   `protobuf:"varint,13,opt,name=ended" json:"ended,omitempty"`
+}
+
+func (m *AgentResultsResponse_DcpStream) Reset()         { *m = AgentResultsResponse_DcpStream{} }
+func (m *AgentResultsResponse_DcpStream) String() string { return proto.CompactTextString(m) }
+func (*AgentResultsResponse_DcpStream) ProtoMessage()    {}
+func (*AgentResultsResponse_DcpStream) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{9, 6}
 }
 
-func (m *AgentResultsResponse) Reset()                    { *m = AgentResultsResponse{} }
-func (m *AgentResultsResponse) String() string            { return proto.CompactTextString(m) }
-func (*AgentResultsResponse) ProtoMessage()               {}
-func (*AgentResultsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (m *AgentResultsResponse_DcpStream) GetVbucket() uint32 {
+	if m != nil {
+		return m.Vbucket
+	}
+	return 0
+}
 
-func (m *AgentResultsResponse) GetStatus() string {
+func (m *AgentResultsResponse_DcpStream) GetStreamId() uint32 {
 	if m != nil {
-		return m.Status
+		return m.StreamId
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_DcpStream) GetStartSeqno() uint64 {
+	if m != nil {
+		return m.StartSeqno
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_DcpStream) GetEndSeqno() uint64 {
+	if m != nil {
+		return m.EndSeqno
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_DcpStream) GetLastSeqno() uint64 {
+	if m != nil {
+		return m.LastSeqno
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_DcpStream) GetMutations() uint64 {
+	if m != nil {
+		return m.Mutations
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_DcpStream) GetDeletions() uint64 {
+	if m != nil {
+		return m.Deletions
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_DcpStream) GetExpirations() uint64 {
+	if m != nil {
+		return m.Expirations
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_DcpStream) GetSnapshots() uint64 {
+	if m != nil {
+		return m.Snapshots
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_DcpStream) GetTotalSnapshotSize() uint64 {
+	if m != nil {
+		return m.TotalSnapshotSize
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_DcpStream) GetMaxSnapshotSize() uint64 {
+	if m != nil {
+		return m.MaxSnapshotSize
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_DcpStream) GetSeqnoLag() uint64 {
+	if m != nil {
+		return m.SeqnoLag
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_DcpStream) GetEnded() bool {
+	if m != nil {
+		return m.Ended
+	}
+	return false
+}
+
+type AgentResultsResponse_DcpConnection struct {
+	Name               string                            `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	BytesSent          uint64                            `protobuf:"varint,2,opt,name=bytesSent" json:"bytesSent,omitempty"`
+	BytesAcked         uint64                            `protobuf:"varint,3,opt,name=bytesAcked" json:"bytesAcked,omitempty"`
+	BufferAcks         uint64                            `protobuf:"varint,4,opt,name=bufferAcks" json:"bufferAcks,omitempty"`
+	MaxUnackedBytes    uint64                            `protobuf:"varint,5,opt,name=maxUnackedBytes" json:"maxUnackedBytes,omitempty"`
+	Rollbacks          uint64                            `protobuf:"varint,6,opt,name=rollbacks" json:"rollbacks,omitempty"`
+	TotalAckLagInNanos int64                             `protobuf:"varint,7,opt,name=totalAckLagInNanos" json:"totalAckLagInNanos,omitempty"`
+	MaxAckLagInNanos   int64                             `protobuf:"varint,8,opt,name=maxAckLagInNanos" json:"maxAckLagInNanos,omitempty"`
+	AckLagSamples      uint64                            `protobuf:"varint,9,opt,name=ackLagSamples" json:"ackLagSamples,omitempty"`
+	Streams            []*AgentResultsResponse_DcpStream `protobuf:"bytes,10,rep,name=streams" json:"streams,omitempty"`
+}
+
+func (m *AgentResultsResponse_DcpConnection) Reset()         { *m = AgentResultsResponse_DcpConnection{} }
+func (m *AgentResultsResponse_DcpConnection) String() string { return proto.CompactTextString(m) }
+func (*AgentResultsResponse_DcpConnection) ProtoMessage()    {}
+func (*AgentResultsResponse_DcpConnection) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{9, 7}
+}
+
+func (m *AgentResultsResponse_DcpConnection) GetName() string {
+	if m != nil {
+		return m.Name
 	}
 	return ""
 }
 
-func (m *AgentResultsResponse) GetCaptureMap() map[string]*AgentResultsResponse_CaptureInfo {
+func (m *AgentResultsResponse_DcpConnection) GetBytesSent() uint64 {
 	if m != nil {
-		return m.CaptureMap
+		return m.BytesSent
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_DcpConnection) GetBytesAcked() uint64 {
+	if m != nil {
+		return m.BytesAcked
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_DcpConnection) GetBufferAcks() uint64 {
+	if m != nil {
+		return m.BufferAcks
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_DcpConnection) GetMaxUnackedBytes() uint64 {
+	if m != nil {
+		return m.MaxUnackedBytes
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_DcpConnection) GetRollbacks() uint64 {
+	if m != nil {
+		return m.Rollbacks
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_DcpConnection) GetTotalAckLagInNanos() int64 {
+	if m != nil {
+		return m.TotalAckLagInNanos
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_DcpConnection) GetMaxAckLagInNanos() int64 {
+	if m != nil {
+		return m.MaxAckLagInNanos
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_DcpConnection) GetAckLagSamples() uint64 {
+	if m != nil {
+		return m.AckLagSamples
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_DcpConnection) GetStreams() []*AgentResultsResponse_DcpStream {
+	if m != nil {
+		return m.Streams
 	}
 	return nil
 }
 
-type AgentResultsResponse_CaptureInfo struct {
-	Oplatency string `protobuf:"bytes,1,opt,name=oplatency" json:"oplatency,omitempty"`
-	Key       string `protobuf:"bytes,2,opt,name=key" json:"key,omitempty"`
-	Opaque    string `protobuf:"bytes,3,opt,name=opaque" json:"opaque,omitempty"`
+type AgentResultsResponse_SubdocPath struct {
+	Opcode              string `protobuf:"bytes,1,opt,name=opcode" json:"opcode,omitempty"`
+	Path                string `protobuf:"bytes,2,opt,name=path" json:"path,omitempty"`
+	Xattr               bool   `protobuf:"varint,3,opt,name=xattr" json:"xattr,omitempty"`
+	Count               uint64 `protobuf:"varint,4,opt,name=count" json:"count,omitempty"`
+	Errors              uint64 `protobuf:"varint,5,opt,name=errors" json:"errors,omitempty"`
+	TotalLatencyInNanos int64  `protobuf:"varint,6,opt,name=totalLatencyInNanos" json:"totalLatencyInNanos,omitempty"`
+	MaxLatencyInNanos   int64  `protobuf:"varint,7,opt,name=maxLatencyInNanos" json:"maxLatencyInNanos,omitempty"`
+	ValueBytes          uint64 `protobuf:"varint,8,opt,name=valueBytes" json:"valueBytes,omitempty"`
 }
 
-func (m *AgentResultsResponse_CaptureInfo) Reset()         { *m = AgentResultsResponse_CaptureInfo{} }
-func (m *AgentResultsResponse_CaptureInfo) String() string { return proto.CompactTextString(m) }
-func (*AgentResultsResponse_CaptureInfo) ProtoMessage()    {}
-func (*AgentResultsResponse_CaptureInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{5, 0}
+func (m *AgentResultsResponse_SubdocPath) Reset()         { *m = AgentResultsResponse_SubdocPath{} }
+func (m *AgentResultsResponse_SubdocPath) String() string { return proto.CompactTextString(m) }
+func (*AgentResultsResponse_SubdocPath) ProtoMessage()    {}
+func (*AgentResultsResponse_SubdocPath) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{9, 8}
 }
 
-func (m *AgentResultsResponse_CaptureInfo) GetOplatency() string {
+func (m *AgentResultsResponse_SubdocPath) GetOpcode() string {
 	if m != nil {
-		return m.Oplatency
+		return m.Opcode
 	}
 	return ""
 }
 
-func (m *AgentResultsResponse_CaptureInfo) GetKey() string {
+func (m *AgentResultsResponse_SubdocPath) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *AgentResultsResponse_SubdocPath) GetXattr() bool {
+	if m != nil {
+		return m.Xattr
+	}
+	return false
+}
+
+func (m *AgentResultsResponse_SubdocPath) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_SubdocPath) GetErrors() uint64 {
+	if m != nil {
+		return m.Errors
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_SubdocPath) GetTotalLatencyInNanos() int64 {
+	if m != nil {
+		return m.TotalLatencyInNanos
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_SubdocPath) GetMaxLatencyInNanos() int64 {
+	if m != nil {
+		return m.MaxLatencyInNanos
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_SubdocPath) GetValueBytes() uint64 {
+	if m != nil {
+		return m.ValueBytes
+	}
+	return 0
+}
+
+type AgentResultsResponse_UnansweredOp struct {
+	Connection       string `protobuf:"bytes,1,opt,name=connection" json:"connection,omitempty"`
+	Opcode           string `protobuf:"bytes,2,opt,name=opcode" json:"opcode,omitempty"`
+	Key              string `protobuf:"bytes,3,opt,name=key" json:"key,omitempty"`
+	Opaque           uint32 `protobuf:"varint,4,opt,name=opaque" json:"opaque,omitempty"`
+	TimestampInNanos int64  `protobuf:"varint,5,opt,name=timestampInNanos" json:"timestampInNanos,omitempty"`
+	AgeInNanos       int64  `protobuf:"varint,6,opt,name=ageInNanos" json:"ageInNanos,omitempty"`
+	ConnectionClosed bool   `protobuf:"varint,7,opt,name=connectionClosed" json:"connectionClosed,omitempty"`
+}
+
+func (m *AgentResultsResponse_UnansweredOp) Reset()         { *m = AgentResultsResponse_UnansweredOp{} }
+func (m *AgentResultsResponse_UnansweredOp) String() string { return proto.CompactTextString(m) }
+func (*AgentResultsResponse_UnansweredOp) ProtoMessage()    {}
+func (*AgentResultsResponse_UnansweredOp) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{9, 9}
+}
+
+func (m *AgentResultsResponse_UnansweredOp) GetConnection() string {
+	if m != nil {
+		return m.Connection
+	}
+	return ""
+}
+
+func (m *AgentResultsResponse_UnansweredOp) GetOpcode() string {
+	if m != nil {
+		return m.Opcode
+	}
+	return ""
+}
+
+func (m *AgentResultsResponse_UnansweredOp) GetKey() string {
 	if m != nil {
 		return m.Key
 	}
 	return ""
 }
 
-func (m *AgentResultsResponse_CaptureInfo) GetOpaque() string {
+func (m *AgentResultsResponse_UnansweredOp) GetOpaque() uint32 {
 	if m != nil {
 		return m.Opaque
 	}
+	return 0
+}
+
+func (m *AgentResultsResponse_UnansweredOp) GetTimestampInNanos() int64 {
+	if m != nil {
+		return m.TimestampInNanos
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_UnansweredOp) GetAgeInNanos() int64 {
+	if m != nil {
+		return m.AgeInNanos
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_UnansweredOp) GetConnectionClosed() bool {
+	if m != nil {
+		return m.ConnectionClosed
+	}
+	return false
+}
+
+type AgentResultsResponse_CaptureStats struct {
+	Device    string `protobuf:"bytes,1,opt,name=device" json:"device,omitempty"`
+	Sniffer   string `protobuf:"bytes,2,opt,name=sniffer" json:"sniffer,omitempty"`
+	Received  uint64 `protobuf:"varint,3,opt,name=received" json:"received,omitempty"`
+	Dropped   uint64 `protobuf:"varint,4,opt,name=dropped" json:"dropped,omitempty"`
+	IfDropped uint64 `protobuf:"varint,5,opt,name=ifDropped" json:"ifDropped,omitempty"`
+	Error     string `protobuf:"bytes,6,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *AgentResultsResponse_CaptureStats) Reset()         { *m = AgentResultsResponse_CaptureStats{} }
+func (m *AgentResultsResponse_CaptureStats) String() string { return proto.CompactTextString(m) }
+func (*AgentResultsResponse_CaptureStats) ProtoMessage()    {}
+func (*AgentResultsResponse_CaptureStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{9, 10}
+}
+
+func (m *AgentResultsResponse_CaptureStats) GetDevice() string {
+	if m != nil {
+		return m.Device
+	}
+	return ""
+}
+
+func (m *AgentResultsResponse_CaptureStats) GetSniffer() string {
+	if m != nil {
+		return m.Sniffer
+	}
+	return ""
+}
+
+func (m *AgentResultsResponse_CaptureStats) GetReceived() uint64 {
+	if m != nil {
+		return m.Received
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_CaptureStats) GetDropped() uint64 {
+	if m != nil {
+		return m.Dropped
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_CaptureStats) GetIfDropped() uint64 {
+	if m != nil {
+		return m.IfDropped
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_CaptureStats) GetError() string {
+	if m != nil {
+		return m.Error
+	}
 	return ""
 }
 
+type AgentResultsResponse_SizeHistogram struct {
+	Buckets        []uint64 `protobuf:"varint,1,rep,packed,name=buckets" json:"buckets,omitempty"`
+	Count          uint64   `protobuf:"varint,2,opt,name=count" json:"count,omitempty"`
+	TotalBytes     uint64   `protobuf:"varint,3,opt,name=totalBytes" json:"totalBytes,omitempty"`
+	Max            uint32   `protobuf:"varint,4,opt,name=max" json:"max,omitempty"`
+	LargeDocuments uint64   `protobuf:"varint,5,opt,name=largeDocuments" json:"largeDocuments,omitempty"`
+}
+
+func (m *AgentResultsResponse_SizeHistogram) Reset()         { *m = AgentResultsResponse_SizeHistogram{} }
+func (m *AgentResultsResponse_SizeHistogram) String() string { return proto.CompactTextString(m) }
+func (*AgentResultsResponse_SizeHistogram) ProtoMessage()    {}
+func (*AgentResultsResponse_SizeHistogram) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{9, 11}
+}
+
+func (m *AgentResultsResponse_SizeHistogram) GetBuckets() []uint64 {
+	if m != nil {
+		return m.Buckets
+	}
+	return nil
+}
+
+func (m *AgentResultsResponse_SizeHistogram) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_SizeHistogram) GetTotalBytes() uint64 {
+	if m != nil {
+		return m.TotalBytes
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_SizeHistogram) GetMax() uint32 {
+	if m != nil {
+		return m.Max
+	}
+	return 0
+}
+
+func (m *AgentResultsResponse_SizeHistogram) GetLargeDocuments() uint64 {
+	if m != nil {
+		return m.LargeDocuments
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*CoordinatorCaptureRequest)(nil), "rpc.CoordinatorCaptureRequest")
 	proto.RegisterType((*AgentCaptureResponse)(nil), "rpc.AgentCaptureResponse")
 	proto.RegisterType((*CoordinatorGoodByeRequest)(nil), "rpc.CoordinatorGoodByeRequest")
 	proto.RegisterType((*AgentGoodByeResponse)(nil), "rpc.AgentGoodByeResponse")
 	proto.RegisterType((*CoordinatorResultsRequest)(nil), "rpc.CoordinatorResultsRequest")
+	proto.RegisterType((*ListRecordingsRequest)(nil), "rpc.ListRecordingsRequest")
+	proto.RegisterType((*ListRecordingsResponse)(nil), "rpc.ListRecordingsResponse")
+	proto.RegisterType((*ListRecordingsResponse_Recording)(nil), "rpc.ListRecordingsResponse.Recording")
+	proto.RegisterType((*DownloadRecordingRequest)(nil), "rpc.DownloadRecordingRequest")
+	proto.RegisterType((*DownloadRecordingResponse)(nil), "rpc.DownloadRecordingResponse")
 	proto.RegisterType((*AgentResultsResponse)(nil), "rpc.AgentResultsResponse")
 	proto.RegisterType((*AgentResultsResponse_CaptureInfo)(nil), "rpc.AgentResultsResponse.CaptureInfo")
+	proto.RegisterType((*AgentResultsResponse_OpcodeErrors)(nil), "rpc.AgentResultsResponse.OpcodeErrors")
+	proto.RegisterType((*AgentResultsResponse_ConnectionInfo)(nil), "rpc.AgentResultsResponse.ConnectionInfo")
+	proto.RegisterType((*AgentResultsResponse_LatencySummary)(nil), "rpc.AgentResultsResponse.LatencySummary")
+	proto.RegisterType((*AgentResultsResponse_ConfigRevision)(nil), "rpc.AgentResultsResponse.ConfigRevision")
+	proto.RegisterType((*AgentResultsResponse_PushMessage)(nil), "rpc.AgentResultsResponse.PushMessage")
+	proto.RegisterType((*AgentResultsResponse_DcpStream)(nil), "rpc.AgentResultsResponse.DcpStream")
+	proto.RegisterType((*AgentResultsResponse_DcpConnection)(nil), "rpc.AgentResultsResponse.DcpConnection")
+	proto.RegisterType((*AgentResultsResponse_SubdocPath)(nil), "rpc.AgentResultsResponse.SubdocPath")
+	proto.RegisterType((*AgentResultsResponse_UnansweredOp)(nil), "rpc.AgentResultsResponse.UnansweredOp")
+	proto.RegisterType((*AgentResultsResponse_CaptureStats)(nil), "rpc.AgentResultsResponse.CaptureStats")
+	proto.RegisterType((*AgentResultsResponse_SizeHistogram)(nil), "rpc.AgentResultsResponse.SizeHistogram")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -175,6 +1451,8 @@ type AgentServiceClient interface {
 	CaptureSignal(ctx context.Context, in *CoordinatorCaptureRequest, opts ...grpc.CallOption) (*AgentCaptureResponse, error)
 	GoodByeSignal(ctx context.Context, in *CoordinatorGoodByeRequest, opts ...grpc.CallOption) (*AgentGoodByeResponse, error)
 	AgentResults(ctx context.Context, in *CoordinatorResultsRequest, opts ...grpc.CallOption) (*AgentResultsResponse, error)
+	ListRecordings(ctx context.Context, in *ListRecordingsRequest, opts ...grpc.CallOption) (*ListRecordingsResponse, error)
+	DownloadRecording(ctx context.Context, in *DownloadRecordingRequest, opts ...grpc.CallOption) (*DownloadRecordingResponse, error)
 }
 
 type agentServiceClient struct {
@@ -212,12 +1490,32 @@ func (c *agentServiceClient) AgentResults(ctx context.Context, in *CoordinatorRe
 	return out, nil
 }
 
+func (c *agentServiceClient) ListRecordings(ctx context.Context, in *ListRecordingsRequest, opts ...grpc.CallOption) (*ListRecordingsResponse, error) {
+	out := new(ListRecordingsResponse)
+	err := grpc.Invoke(ctx, "/rpc.AgentService/ListRecordings", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentServiceClient) DownloadRecording(ctx context.Context, in *DownloadRecordingRequest, opts ...grpc.CallOption) (*DownloadRecordingResponse, error) {
+	out := new(DownloadRecordingResponse)
+	err := grpc.Invoke(ctx, "/rpc.AgentService/DownloadRecording", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for AgentService service
 
 type AgentServiceServer interface {
 	CaptureSignal(context.Context, *CoordinatorCaptureRequest) (*AgentCaptureResponse, error)
 	GoodByeSignal(context.Context, *CoordinatorGoodByeRequest) (*AgentGoodByeResponse, error)
 	AgentResults(context.Context, *CoordinatorResultsRequest) (*AgentResultsResponse, error)
+	ListRecordings(context.Context, *ListRecordingsRequest) (*ListRecordingsResponse, error)
+	DownloadRecording(context.Context, *DownloadRecordingRequest) (*DownloadRecordingResponse, error)
 }
 
 func RegisterAgentServiceServer(s *grpc.Server, srv AgentServiceServer) {
@@ -278,6 +1576,42 @@ func _AgentService_AgentResults_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AgentService_ListRecordings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRecordingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).ListRecordings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.AgentService/ListRecordings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).ListRecordings(ctx, req.(*ListRecordingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentService_DownloadRecording_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DownloadRecordingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).DownloadRecording(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.AgentService/DownloadRecording",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).DownloadRecording(ctx, req.(*DownloadRecordingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _AgentService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "rpc.AgentService",
 	HandlerType: (*AgentServiceServer)(nil),
@@ -294,6 +1628,14 @@ var _AgentService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "AgentResults",
 			Handler:    _AgentService_AgentResults_Handler,
 		},
+		{
+			MethodName: "ListRecordings",
+			Handler:    _AgentService_ListRecordings_Handler,
+		},
+		{
+			MethodName: "DownloadRecording",
+			Handler:    _AgentService_DownloadRecording_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "AgentService.proto",
@@ -302,26 +1644,151 @@ var _AgentService_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("AgentService.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 334 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x94, 0x92, 0xcd, 0x4a, 0xc3, 0x40,
-	0x14, 0x85, 0x9b, 0x04, 0x0b, 0xbd, 0x55, 0x94, 0x41, 0x24, 0xad, 0x22, 0x25, 0x20, 0xd4, 0x4d,
-	0x16, 0x75, 0x23, 0xba, 0xd2, 0x22, 0x52, 0xd4, 0x4d, 0x8a, 0x0f, 0x30, 0xa6, 0xd7, 0x52, 0x0c,
-	0x33, 0xd3, 0xf9, 0x29, 0xe4, 0xc5, 0x7c, 0x35, 0xb7, 0x92, 0x64, 0x9a, 0xa4, 0xa9, 0x55, 0xdc,
-	0x65, 0x4e, 0xe6, 0x7c, 0xf7, 0xdc, 0xe4, 0x00, 0xb9, 0x9b, 0x23, 0xd3, 0x53, 0x94, 0xab, 0x45,
-	0x8c, 0xa1, 0x90, 0x5c, 0x73, 0xe2, 0x49, 0x11, 0x07, 0xa7, 0xd0, 0x1b, 0x73, 0x2e, 0x67, 0x0b,
-	0x46, 0x35, 0x97, 0x63, 0x2a, 0xb4, 0x91, 0x18, 0xe1, 0xd2, 0xa0, 0xd2, 0x41, 0x08, 0xc7, 0xb9,
-	0xaf, 0x94, 0x95, 0xe0, 0x4c, 0x21, 0x39, 0x81, 0xb6, 0xd2, 0x54, 0x1b, 0xe5, 0x3b, 0x03, 0x67,
-	0xd8, 0x89, 0xec, 0xa9, 0x01, 0x7b, 0xe4, 0x7c, 0x76, 0x9f, 0x6e, 0xc1, 0x4a, 0xf9, 0x5f, 0xb0,
-	0x08, 0x95, 0x49, 0xb4, 0x5a, 0xc3, 0x3e, 0x5d, 0x4b, 0x2b, 0xf5, 0xdf, 0x69, 0x64, 0x02, 0x10,
-	0x17, 0x5b, 0xbc, 0x50, 0xe1, 0xbb, 0x03, 0x6f, 0xd8, 0x1d, 0x5d, 0x86, 0x52, 0xc4, 0xe1, 0x4f,
-	0x98, 0x70, 0x5c, 0xde, 0x7d, 0x60, 0x5a, 0xa6, 0x51, 0xcd, 0xdc, 0x7f, 0x85, 0xae, 0x7d, 0x3d,
-	0x61, 0xef, 0x9c, 0x9c, 0x41, 0x87, 0x8b, 0x84, 0x6a, 0x64, 0x71, 0x6a, 0x87, 0x56, 0x02, 0x39,
-	0x02, 0xef, 0x03, 0x53, 0xdf, 0xcd, 0xf5, 0xec, 0x31, 0x4b, 0xc8, 0x05, 0x5d, 0x1a, 0xf4, 0xbd,
-	0x22, 0x61, 0x71, 0xea, 0xcf, 0xe0, 0xb0, 0x31, 0x75, 0x6d, 0x76, 0x2a, 0xf3, 0x2d, 0xec, 0xad,
-	0x68, 0x62, 0x30, 0x07, 0x76, 0x47, 0x17, 0x7f, 0x6e, 0x90, 0x45, 0x8c, 0x0a, 0xcf, 0x8d, 0x7b,
-	0xed, 0x8c, 0xbe, 0x1c, 0xd8, 0xaf, 0x77, 0x81, 0x3c, 0xc3, 0x81, 0xbd, 0x3a, 0x5d, 0xcc, 0x19,
-	0x4d, 0xc8, 0x79, 0xce, 0xdc, 0x59, 0x8a, 0x7e, 0xaf, 0x9a, 0xd9, 0xe8, 0x45, 0xd0, 0xca, 0x68,
-	0xf6, 0xff, 0xee, 0xa2, 0x6d, 0xb6, 0xa2, 0x4e, 0x6b, 0x14, 0x23, 0x68, 0x91, 0x27, 0x9b, 0xd5,
-	0xee, 0xb6, 0x0d, 0xdb, 0x6c, 0x45, 0x1d, 0xd6, 0xf8, 0x1c, 0x41, 0xeb, 0xad, 0x9d, 0xb7, 0xfe,
-	0xea, 0x3b, 0x00, 0x00, 0xff, 0xff, 0xc8, 0x09, 0x2c, 0x60, 0x0b, 0x03, 0x00, 0x00,
+	// 2323 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xb4, 0x59, 0xdd, 0x6e, 0x1c, 0x49,
+	0xf5, 0xdf, 0xf1, 0x8c, 0xed, 0x99, 0xe3, 0xb1, 0x63, 0x57, 0x9c, 0x6c, 0xa7, 0x37, 0x1b, 0x59,
+	0xfe, 0xef, 0x3f, 0x98, 0x65, 0x31, 0x28, 0x41, 0xe2, 0x6b, 0x77, 0x51, 0x62, 0x87, 0x60, 0xd6,
+	0xd9, 0x44, 0xed, 0x0d, 0x08, 0xad, 0x84, 0x54, 0xee, 0xae, 0x19, 0x37, 0x33, 0xd3, 0xd5, 0xa9,
+	0xaa, 0xf6, 0x7a, 0xf6, 0x01, 0xb8, 0xe0, 0x0d, 0xe0, 0x0e, 0x71, 0xc7, 0x1b, 0x70, 0x85, 0xb8,
+	0xe2, 0x15, 0x90, 0x78, 0x01, 0x1e, 0x82, 0x0b, 0x74, 0xaa, 0xaa, 0xbb, 0xab, 0x3f, 0x66, 0x9c,
+	0x68, 0x95, 0xab, 0x99, 0xf3, 0xab, 0x73, 0x7e, 0xf5, 0x75, 0xea, 0x9c, 0x53, 0xd5, 0x40, 0x1e,
+	0x8d, 0x59, 0xa2, 0xce, 0x98, 0xb8, 0x8c, 0x43, 0x76, 0x98, 0x0a, 0xae, 0x38, 0xe9, 0x8a, 0x34,
+	0xdc, 0x7f, 0x0f, 0xee, 0x1c, 0x71, 0x2e, 0xa2, 0x38, 0xa1, 0x8a, 0x8b, 0x23, 0x9a, 0xaa, 0x4c,
+	0xb0, 0x80, 0xbd, 0xca, 0x98, 0x54, 0xfb, 0x87, 0xb0, 0xab, 0xed, 0x0a, 0x58, 0xa6, 0x3c, 0x91,
+	0x8c, 0xdc, 0x86, 0x35, 0xa9, 0xa8, 0xca, 0xa4, 0xd7, 0xd9, 0xeb, 0x1c, 0x0c, 0x02, 0x2b, 0xd5,
+	0xc8, 0x9e, 0x72, 0x1e, 0x3d, 0x9e, 0x37, 0xc8, 0x0a, 0xf8, 0x8d, 0xc8, 0x02, 0x26, 0xb3, 0xa9,
+	0x92, 0x39, 0xd9, 0xbb, 0x70, 0xeb, 0x34, 0x96, 0x2a, 0x60, 0xa1, 0xd6, 0x18, 0x17, 0x0d, 0xff,
+	0x58, 0x81, 0xdb, 0xf5, 0x96, 0xe5, 0x1d, 0x91, 0x27, 0x00, 0xa2, 0xd0, 0xf6, 0x56, 0xf6, 0xba,
+	0x07, 0x1b, 0x0f, 0xfe, 0xff, 0x50, 0xa4, 0xe1, 0x61, 0x3b, 0xd1, 0x61, 0x01, 0x05, 0x8e, 0xa1,
+	0xff, 0xcf, 0x0e, 0x0c, 0x8a, 0x16, 0x42, 0xa0, 0x97, 0xd0, 0x19, 0xb3, 0x5d, 0xe9, 0xff, 0x88,
+	0xc9, 0xf8, 0x6b, 0xe6, 0xad, 0xec, 0x75, 0x0e, 0x7a, 0x81, 0xfe, 0x4f, 0x0e, 0x81, 0x8c, 0x62,
+	0x21, 0xd5, 0x0b, 0x1a, 0x4e, 0x98, 0x3a, 0x49, 0x3e, 0xa7, 0x09, 0x97, 0x5e, 0x77, 0xaf, 0x73,
+	0xd0, 0x0d, 0x5a, 0x5a, 0xc8, 0x47, 0xb0, 0x33, 0xa5, 0x75, 0xf5, 0x9e, 0x56, 0x6f, 0x36, 0x90,
+	0xbb, 0x30, 0x48, 0x05, 0x93, 0x4c, 0x5c, 0xb2, 0xc8, 0x5b, 0xdd, 0xeb, 0x1c, 0xf4, 0x83, 0x12,
+	0xc0, 0x05, 0xa1, 0xa1, 0x8a, 0x2f, 0x99, 0xb7, 0xa6, 0x9b, 0xac, 0xb4, 0xff, 0x5b, 0xf0, 0x8e,
+	0xf9, 0x57, 0xc9, 0x94, 0xd3, 0xa8, 0x9c, 0xaa, 0x59, 0xdf, 0xd6, 0x79, 0xdd, 0x86, 0x35, 0x3e,
+	0x1a, 0x49, 0xa6, 0xec, 0xcc, 0xac, 0x84, 0xf8, 0x94, 0x25, 0x63, 0x75, 0xa1, 0xe7, 0xb3, 0x19,
+	0x58, 0x69, 0xff, 0x37, 0x70, 0xa7, 0x85, 0xff, 0x9a, 0x5d, 0x22, 0xd0, 0x8b, 0xa8, 0xa2, 0xba,
+	0x8b, 0x61, 0xa0, 0xff, 0x93, 0x6d, 0xe8, 0x32, 0x3e, 0xd2, 0xec, 0xfd, 0x00, 0xff, 0xee, 0xff,
+	0xe5, 0xa1, 0xf5, 0xb2, 0xc2, 0x5f, 0xae, 0xa1, 0x3d, 0x01, 0x08, 0x8d, 0x77, 0x3f, 0xa3, 0xa9,
+	0xdd, 0xfc, 0x6f, 0xeb, 0xcd, 0x6f, 0xa3, 0x39, 0x3c, 0x2a, 0x74, 0x9f, 0x24, 0x4a, 0xcc, 0x03,
+	0xc7, 0x98, 0x3c, 0x87, 0xa1, 0x21, 0x3d, 0xe2, 0x59, 0xa2, 0x70, 0x13, 0x91, 0xec, 0x3b, 0x8b,
+	0xc9, 0xce, 0x1c, 0x6d, 0x43, 0x57, 0x21, 0x40, 0x42, 0x9e, 0x86, 0x3c, 0x62, 0x4f, 0x84, 0xe0,
+	0x02, 0xb7, 0xf9, 0x1a, 0xc2, 0xe7, 0x8e, 0xb6, 0x25, 0x74, 0x09, 0xc8, 0x7d, 0xd8, 0x12, 0x8c,
+	0x4a, 0xc9, 0x66, 0xe7, 0xd3, 0xf9, 0x53, 0x9a, 0x4a, 0xed, 0x13, 0xbd, 0xa0, 0x86, 0x92, 0x7d,
+	0x18, 0xca, 0x49, 0x9c, 0xa6, 0x2c, 0x7a, 0x3c, 0x57, 0x4c, 0x6a, 0xf7, 0xe8, 0x05, 0x15, 0x8c,
+	0x9c, 0xc2, 0x46, 0xc8, 0x93, 0x84, 0x85, 0x2a, 0xe6, 0x89, 0xf4, 0xd6, 0xf5, 0xd8, 0x3e, 0x5c,
+	0xb2, 0x72, 0xa5, 0xb2, 0x19, 0x9a, 0x6b, 0x8e, 0x53, 0x15, 0xc6, 0xc3, 0xce, 0xe2, 0xaf, 0x99,
+	0xf4, 0xfa, 0xd7, 0x4d, 0x35, 0x70, 0xb4, 0xed, 0x54, 0x5d, 0x02, 0x12, 0xc0, 0xa6, 0xb0, 0xba,
+	0x86, 0x71, 0xa0, 0x19, 0x3f, 0x5a, 0xc6, 0xe8, 0xa8, 0x1b, 0xca, 0x2a, 0x05, 0x89, 0xe0, 0x66,
+	0xc8, 0xa7, 0x53, 0x33, 0xe6, 0x53, 0xaa, 0x58, 0x12, 0xc6, 0x4c, 0x7a, 0xa0, 0x99, 0x1f, 0x2c,
+	0x9b, 0x7a, 0xc3, 0xc8, 0xf0, 0xb7, 0xd1, 0x69, 0x37, 0x8a, 0x26, 0x25, 0xfd, 0xc6, 0xb5, 0x6e,
+	0x14, 0x4d, 0x6a, 0xbc, 0x15, 0x02, 0xf2, 0x25, 0x6c, 0x5f, 0x9e, 0x67, 0x18, 0x16, 0x4a, 0xd2,
+	0xa1, 0x26, 0xfd, 0xde, 0x62, 0xd2, 0x5f, 0xd5, 0x2c, 0x0c, 0x71, 0x83, 0x88, 0x8c, 0x61, 0x37,
+	0xe1, 0xea, 0xd9, 0xdc, 0xea, 0x3f, 0x9e, 0x1f, 0x4d, 0x63, 0x96, 0x28, 0x6f, 0x53, 0x77, 0xf0,
+	0x70, 0x71, 0x07, 0x9f, 0xb7, 0x58, 0x99, 0x4e, 0x5a, 0x09, 0x49, 0x00, 0x37, 0x42, 0x9e, 0x8c,
+	0xe2, 0x71, 0xc0, 0x2e, 0x63, 0xa9, 0x7d, 0x6e, 0x4b, 0xf7, 0x71, 0xb0, 0xd4, 0xe7, 0x1c, 0x83,
+	0xa0, 0x4e, 0x40, 0x4e, 0x60, 0x98, 0x66, 0xf2, 0xe2, 0x19, 0x93, 0x92, 0x8e, 0x99, 0xf4, 0x6e,
+	0x38, 0xb1, 0xbf, 0x95, 0xf0, 0x45, 0xa9, 0x1d, 0x54, 0x4c, 0xc9, 0x4b, 0xd8, 0x8a, 0xc2, 0xd4,
+	0x71, 0x72, 0x6f, 0x5b, 0x93, 0x7d, 0x77, 0x31, 0xd9, 0x71, 0x45, 0xdf, 0xcc, 0xbd, 0x46, 0x42,
+	0x7e, 0x0e, 0x1b, 0x32, 0x3b, 0x8f, 0x78, 0xf8, 0x82, 0xaa, 0x0b, 0xe9, 0xed, 0x68, 0xce, 0x0f,
+	0x96, 0xf8, 0x42, 0xa1, 0x1c, 0xb8, 0x86, 0x44, 0x82, 0x27, 0x18, 0x8d, 0x9e, 0xf1, 0x28, 0x1e,
+	0xcd, 0x7f, 0x2d, 0x62, 0xc5, 0x64, 0xb1, 0x55, 0x44, 0x93, 0xfe, 0x70, 0xd9, 0xc9, 0x68, 0xb7,
+	0x34, 0x43, 0x5e, 0x48, 0x4c, 0x4e, 0x61, 0x33, 0x4b, 0x68, 0x22, 0xbf, 0x62, 0x82, 0x45, 0xcf,
+	0x53, 0xe9, 0xdd, 0xd4, 0x3d, 0xdd, 0x5f, 0xdc, 0xd3, 0x4b, 0x47, 0x3d, 0xa8, 0x1a, 0x93, 0x5f,
+	0xc2, 0xd0, 0x06, 0x5b, 0x8c, 0x9b, 0xd2, 0xdb, 0xbd, 0x8e, 0xec, 0xc8, 0xd1, 0x0e, 0x2a, 0xb6,
+	0xfe, 0xbf, 0xd7, 0x60, 0xc3, 0x36, 0x9f, 0x24, 0x23, 0x8e, 0x79, 0x92, 0xa7, 0x53, 0xed, 0xd4,
+	0x73, 0x9b, 0x20, 0x4a, 0x00, 0xd3, 0xcc, 0x84, 0xcd, 0x75, 0xe6, 0x19, 0x04, 0xf8, 0x57, 0x67,
+	0xbc, 0x94, 0xbe, 0xca, 0x98, 0xce, 0x3d, 0x83, 0xc0, 0x4a, 0x06, 0xc7, 0x80, 0xeb, 0xf5, 0x72,
+	0x1c, 0x25, 0x27, 0xfb, 0xac, 0x56, 0xb2, 0xcf, 0x7d, 0xd8, 0xd2, 0xb9, 0x58, 0x44, 0x99, 0xa0,
+	0xb8, 0xe3, 0x3a, 0xd4, 0x0e, 0x82, 0x1a, 0x4a, 0xee, 0x01, 0xe0, 0xff, 0xf3, 0x78, 0x1a, 0xab,
+	0xb9, 0xb7, 0xae, 0x75, 0x1c, 0x84, 0x7c, 0x08, 0xdb, 0xf1, 0x2c, 0x65, 0x42, 0xf2, 0x84, 0x2a,
+	0x16, 0xbd, 0x94, 0x4c, 0x78, 0x7d, 0xad, 0xd5, 0xc0, 0x89, 0x0f, 0x7d, 0xa9, 0x04, 0xa3, 0xb3,
+	0x93, 0xc8, 0x1b, 0xe8, 0xbc, 0x5c, 0xc8, 0x38, 0x1e, 0x3b, 0xe9, 0xbc, 0xb4, 0x00, 0x5d, 0x5a,
+	0xd4, 0x50, 0xf2, 0x03, 0xb8, 0x65, 0x46, 0x78, 0x6c, 0x47, 0x98, 0xab, 0x6f, 0x68, 0xf5, 0xf6,
+	0x46, 0x1c, 0xa5, 0x8a, 0x67, 0x4c, 0x2a, 0x3a, 0x4b, 0x73, 0x83, 0xa1, 0x36, 0x68, 0xe0, 0x38,
+	0x63, 0x1b, 0xcf, 0x8f, 0xa8, 0xf4, 0x36, 0x75, 0x02, 0x72, 0x10, 0xb2, 0x07, 0x1b, 0x79, 0x70,
+	0x46, 0x85, 0x2d, 0xad, 0xe0, 0x42, 0xe4, 0x00, 0x6e, 0x58, 0xfd, 0x63, 0xaa, 0xa8, 0x9a, 0xa7,
+	0xcc, 0xbb, 0xa1, 0x97, 0xa4, 0x0e, 0xe3, 0xb8, 0x72, 0xc3, 0x42, 0x75, 0xdb, 0xac, 0x5e, 0x1d,
+	0x37, 0xfd, 0x16, 0x79, 0xc6, 0xdb, 0xd1, 0x0b, 0xe8, 0x42, 0x98, 0x3c, 0xdd, 0xb4, 0xe1, 0x11,
+	0xad, 0x52, 0xc1, 0xc8, 0x07, 0xb0, 0x39, 0xa5, 0x62, 0xcc, 0x8e, 0x79, 0x98, 0xcd, 0xf0, 0x0c,
+	0xde, 0xd4, 0x25, 0x4c, 0x15, 0x44, 0xaf, 0x31, 0x41, 0xd0, 0xdb, 0x35, 0x5e, 0x63, 0x24, 0x2c,
+	0x85, 0x32, 0xdc, 0xe1, 0x5b, 0x1a, 0xd5, 0xff, 0xb1, 0xd7, 0x32, 0x99, 0x9c, 0x44, 0xde, 0x6d,
+	0xd3, 0xab, 0x8b, 0xe1, 0x9a, 0x96, 0xb2, 0xf7, 0xae, 0xf1, 0xa2, 0x12, 0x41, 0x3f, 0x97, 0xd1,
+	0xc4, 0xf3, 0x8c, 0x9f, 0xcb, 0x68, 0x42, 0x3c, 0x58, 0xb7, 0x11, 0xdf, 0xbb, 0xa3, 0x09, 0x73,
+	0x11, 0xbd, 0x28, 0x4e, 0x46, 0x4c, 0x08, 0x16, 0x79, 0xbe, 0x1e, 0x7c, 0x21, 0xfb, 0x1f, 0xc3,
+	0xd0, 0xad, 0x44, 0xc8, 0x2e, 0xac, 0x2a, 0xae, 0xe8, 0x54, 0x9f, 0xac, 0x5e, 0x60, 0x04, 0x9c,
+	0x1d, 0xd3, 0xed, 0x79, 0xd5, 0x68, 0x24, 0xff, 0x6f, 0xab, 0xb0, 0x55, 0x86, 0x40, 0x7d, 0x3c,
+	0xbf, 0x84, 0x8d, 0x94, 0x0a, 0x69, 0xf9, 0xbc, 0x8e, 0x3e, 0xf9, 0x3f, 0x7e, 0x9d, 0x5a, 0x03,
+	0xcd, 0x0f, 0x5f, 0x94, 0xb6, 0xb6, 0xf4, 0x70, 0xd8, 0x70, 0x8e, 0x82, 0xc9, 0x79, 0x12, 0xe6,
+	0x03, 0xc9, 0xc5, 0x96, 0x72, 0xa9, 0xfb, 0x5a, 0xe5, 0x52, 0xaf, 0xa5, 0x5c, 0x2a, 0xf7, 0x72,
+	0xb5, 0x75, 0x2f, 0xd7, 0x9c, 0xbd, 0xbc, 0x0b, 0x03, 0xfc, 0xd5, 0x53, 0xb3, 0x87, 0xbd, 0x04,
+	0xf0, 0xc6, 0x10, 0xea, 0xf8, 0xea, 0xcc, 0x32, 0xb2, 0xa7, 0xbd, 0xa5, 0x05, 0x77, 0x6a, 0xc4,
+	0x28, 0x86, 0x3a, 0x53, 0x04, 0x0d, 0x82, 0x42, 0xc6, 0x51, 0x19, 0x0b, 0x7d, 0xce, 0x07, 0x81,
+	0x95, 0x70, 0x46, 0x6e, 0x12, 0xd6, 0xc7, 0xba, 0x17, 0x54, 0x30, 0x73, 0x6a, 0xaa, 0x91, 0x5f,
+	0x9f, 0xe6, 0x5e, 0xd0, 0xc0, 0xd1, 0xf3, 0xca, 0x60, 0x9e, 0x9f, 0xe6, 0x12, 0xd1, 0x71, 0x27,
+	0x9e, 0xb0, 0xe9, 0xfc, 0x8b, 0x78, 0xc6, 0x78, 0xa6, 0xf2, 0x03, 0x5d, 0x43, 0x71, 0xee, 0x82,
+	0x71, 0x11, 0xa1, 0x51, 0xbe, 0xdb, 0x52, 0x1f, 0xeb, 0x5e, 0xd0, 0xd2, 0x82, 0xfa, 0x59, 0x32,
+	0xa3, 0x2a, 0xbc, 0x70, 0xf5, 0xb7, 0x8d, 0x7e, 0xb3, 0xc5, 0xff, 0x14, 0xb6, 0xeb, 0xce, 0x92,
+	0x47, 0xff, 0x4e, 0x19, 0xfd, 0x77, 0x61, 0xf5, 0x92, 0x4e, 0xb3, 0xfc, 0x22, 0x67, 0x84, 0x9f,
+	0xac, 0xfc, 0xa8, 0xe3, 0xff, 0xbe, 0x03, 0x5b, 0xa6, 0x36, 0x9a, 0x9f, 0x65, 0xb3, 0x19, 0x15,
+	0x5a, 0x39, 0xe4, 0x59, 0xa2, 0x72, 0xe7, 0xd7, 0x02, 0xf9, 0x3e, 0xdc, 0xd4, 0xa7, 0xe0, 0xb4,
+	0x1a, 0x6d, 0x57, 0x74, 0x34, 0x6c, 0x6b, 0xc2, 0x8b, 0xdf, 0x8c, 0x5e, 0xd5, 0xf4, 0xcd, 0x3d,
+	0xb1, 0xd9, 0xe0, 0xff, 0xab, 0xa3, 0x0f, 0x91, 0x53, 0xed, 0xb4, 0x46, 0xdf, 0xce, 0x82, 0xe8,
+	0x5b, 0x7a, 0xeb, 0x4a, 0xc5, 0x5b, 0x7d, 0xe8, 0x0b, 0x76, 0xf9, 0x24, 0xe5, 0xe1, 0x85, 0xed,
+	0xbb, 0x90, 0x71, 0x9d, 0x04, 0xbb, 0xb4, 0x77, 0x51, 0xfc, 0x8b, 0x2c, 0x92, 0x67, 0x22, 0x64,
+	0x45, 0xd6, 0xd3, 0x12, 0x2e, 0x49, 0xc2, 0x23, 0x7d, 0xaf, 0x40, 0x77, 0x34, 0x02, 0xfa, 0x80,
+	0xe2, 0x29, 0x9f, 0xf2, 0xf1, 0xfc, 0xe8, 0x82, 0x26, 0x63, 0xa6, 0x5d, 0xbf, 0x1f, 0xd4, 0x50,
+	0xff, 0xef, 0x1d, 0xd8, 0x70, 0xea, 0x30, 0x27, 0xe7, 0x76, 0x2a, 0x39, 0xb7, 0x6d, 0xbe, 0x2b,
+	0x0b, 0xe6, 0xdb, 0xcc, 0x7b, 0xdd, 0xd6, 0xbc, 0x57, 0xe6, 0xf1, 0x5e, 0x25, 0x8f, 0x5b, 0x1f,
+	0x59, 0x2d, 0x7d, 0xc4, 0x87, 0x7e, 0xe1, 0xef, 0xe6, 0x76, 0x5d, 0xc8, 0xfe, 0x9f, 0xbb, 0x30,
+	0x38, 0x0e, 0xd3, 0x33, 0x9d, 0x75, 0xdd, 0x18, 0xdb, 0x69, 0xc4, 0xd8, 0x22, 0x53, 0xaf, 0xd4,
+	0x32, 0xf5, 0x3d, 0x00, 0xa9, 0xa8, 0x50, 0x67, 0xec, 0x55, 0xc2, 0x6d, 0x5c, 0x72, 0x10, 0xb4,
+	0x65, 0x49, 0x64, 0x5a, 0x4d, 0x3c, 0x2a, 0x64, 0x8c, 0x2f, 0xf8, 0x54, 0x60, 0x1a, 0xcd, 0x0d,
+	0xb0, 0x04, 0xb0, 0x75, 0x96, 0x29, 0x6a, 0x8a, 0x58, 0x73, 0xf3, 0x2b, 0x01, 0x6c, 0x8d, 0xd8,
+	0x94, 0xe5, 0x97, 0x3e, 0xdd, 0x5a, 0x00, 0x98, 0x1d, 0xd9, 0x55, 0x1a, 0x0b, 0x6b, 0xdd, 0x37,
+	0x59, 0xd9, 0x81, 0xd0, 0x5e, 0x26, 0x34, 0x95, 0x17, 0x5c, 0x49, 0x5d, 0x7e, 0xf4, 0x82, 0x12,
+	0x40, 0x27, 0xd7, 0xbe, 0x7f, 0x66, 0x11, 0x9d, 0x40, 0x41, 0x6b, 0x35, 0x1b, 0x30, 0xc3, 0xcf,
+	0xe8, 0x55, 0x45, 0xd7, 0x04, 0xaa, 0x3a, 0xac, 0x57, 0x12, 0x27, 0x77, 0x4a, 0xc7, 0x36, 0x46,
+	0x15, 0x32, 0x7a, 0x23, 0x4b, 0x22, 0x1b, 0x96, 0xfa, 0x81, 0x11, 0xfc, 0x3f, 0x74, 0x61, 0xb3,
+	0x52, 0xa0, 0xb7, 0xbe, 0x7c, 0xdc, 0x85, 0xc1, 0x39, 0x86, 0xf7, 0x33, 0x0c, 0xa1, 0x26, 0x1a,
+	0x94, 0x00, 0xee, 0x91, 0x16, 0x1e, 0x85, 0x13, 0x16, 0xe5, 0x7b, 0x54, 0x22, 0xba, 0x3d, 0x1b,
+	0x8d, 0x98, 0x78, 0x14, 0x4e, 0xf2, 0xac, 0xe1, 0x20, 0x76, 0x7e, 0x2f, 0x13, 0x7c, 0xd2, 0xb1,
+	0xa9, 0x65, 0xb5, 0x98, 0x9f, 0x0b, 0xe3, 0x38, 0x04, 0x9f, 0x4e, 0xcf, 0x69, 0x38, 0x29, 0xf6,
+	0xac, 0x00, 0x30, 0x0a, 0xea, 0xc5, 0x7b, 0x14, 0x4e, 0x4e, 0xe9, 0x38, 0xf7, 0xf0, 0x75, 0xf3,
+	0xc6, 0xd4, 0x6c, 0xc1, 0x93, 0x33, 0xa3, 0x57, 0x55, 0xed, 0xbe, 0x39, 0x39, 0x75, 0x1c, 0x2b,
+	0x19, 0xaa, 0x81, 0x33, 0x3a, 0x4b, 0xa7, 0x2c, 0xdf, 0xd3, 0x2a, 0x48, 0x3e, 0x81, 0x75, 0xe3,
+	0xb9, 0xf9, 0x6d, 0xf9, 0xff, 0x96, 0x5e, 0x8b, 0xcc, 0xc9, 0x08, 0x72, 0x1b, 0xff, 0xbf, 0x1d,
+	0x80, 0xf2, 0x66, 0xb3, 0xf0, 0xc4, 0x13, 0xe8, 0xa5, 0x54, 0x5d, 0xd8, 0x98, 0xa5, 0xff, 0xe3,
+	0xee, 0x5e, 0x51, 0xa5, 0x84, 0x7d, 0x24, 0x32, 0x42, 0x19, 0x94, 0x7b, 0x6e, 0x50, 0x2e, 0x2b,
+	0x92, 0x55, 0xb7, 0x22, 0x59, 0x14, 0xac, 0xd7, 0xde, 0x30, 0x58, 0xaf, 0x2f, 0x08, 0xd6, 0xe8,
+	0x07, 0x3a, 0x85, 0x98, 0x2d, 0x36, 0x87, 0xc6, 0x41, 0xfc, 0xff, 0x74, 0x60, 0xe8, 0xde, 0x8c,
+	0x4c, 0x21, 0x97, 0x3b, 0xa6, 0x5d, 0x04, 0x07, 0x71, 0x16, 0x68, 0xa5, 0xb2, 0x40, 0x36, 0x4c,
+	0x75, 0xdb, 0x2e, 0x32, 0x3d, 0xf3, 0x44, 0x67, 0xa4, 0xd6, 0xe0, 0xb9, 0xba, 0xb8, 0x54, 0xa7,
+	0x63, 0x56, 0x5d, 0x15, 0x07, 0x41, 0xae, 0x72, 0x6c, 0x47, 0x53, 0x2e, 0x59, 0x64, 0x43, 0x7b,
+	0x03, 0xf7, 0xff, 0xda, 0x81, 0xa1, 0x7b, 0x6f, 0xc3, 0x01, 0x46, 0x0c, 0x1f, 0xad, 0xf3, 0xbd,
+	0x36, 0x12, 0x46, 0x4d, 0x99, 0xc4, 0x78, 0x54, 0xec, 0x1c, 0x73, 0xd1, 0xe4, 0xa8, 0x90, 0xc5,
+	0x97, 0xc5, 0x99, 0x2b, 0x64, 0xb4, 0x8a, 0x04, 0xc7, 0xaa, 0xcc, 0xee, 0x7c, 0x2e, 0xe2, 0x09,
+	0x8a, 0x47, 0xc7, 0xb6, 0xcd, 0xc6, 0xc4, 0x02, 0xd0, 0x31, 0x02, 0x7d, 0xc1, 0x96, 0x69, 0x46,
+	0xf0, 0xff, 0xd8, 0x81, 0x4d, 0x0c, 0x2f, 0xbf, 0x88, 0xa5, 0xe2, 0x63, 0x61, 0x62, 0xb9, 0x89,
+	0xdd, 0xa6, 0x48, 0xed, 0x05, 0xb9, 0x58, 0x7a, 0xdc, 0x8a, 0xeb, 0x71, 0xf7, 0x00, 0xb4, 0xfb,
+	0x98, 0x9d, 0xb7, 0x11, 0xa2, 0x44, 0x70, 0xc3, 0x66, 0xf4, 0xca, 0xee, 0x0d, 0xfe, 0x35, 0x99,
+	0xca, 0xb9, 0x24, 0x14, 0x4f, 0x78, 0x55, 0xd4, 0x8f, 0xe0, 0x46, 0xed, 0xad, 0xb2, 0xa5, 0x90,
+	0xf9, 0xa9, 0x5b, 0xc8, 0x2c, 0x7d, 0xf8, 0x70, 0x2e, 0xcb, 0x6e, 0xbd, 0xf3, 0x33, 0xd8, 0x69,
+	0x3c, 0x62, 0xbe, 0x51, 0xc1, 0x34, 0x86, 0x9d, 0xc6, 0xa3, 0x65, 0x0b, 0xc1, 0xc7, 0xd5, 0x81,
+	0xde, 0x7f, 0xbd, 0x27, 0x50, 0xb7, 0xa3, 0x0b, 0xd8, 0xae, 0x3f, 0xb6, 0xb4, 0xf4, 0xf3, 0x69,
+	0xb5, 0x9f, 0x83, 0xd7, 0xbd, 0x62, 0x54, 0x7b, 0xda, 0x69, 0x3c, 0x4e, 0xb6, 0x74, 0xf5, 0x49,
+	0xb5, 0xab, 0x6f, 0x2d, 0x79, 0xd3, 0x71, 0x5d, 0xcc, 0xed, 0x29, 0x06, 0xd2, 0x7c, 0xb4, 0x7c,
+	0x3b, 0x5d, 0xa5, 0xe0, 0x2d, 0x7a, 0xc5, 0xfc, 0x46, 0xcb, 0x58, 0x2d, 0x96, 0xab, 0x93, 0xdb,
+	0x69, 0x3c, 0x6c, 0xbe, 0xa5, 0xae, 0x66, 0x70, 0xab, 0xf5, 0xb9, 0xf3, 0x2d, 0x75, 0xf7, 0x14,
+	0xee, 0x2c, 0x7c, 0xfc, 0x7c, 0xa3, 0xc3, 0xf3, 0x3b, 0xb8, 0xd9, 0xf2, 0x86, 0xf8, 0x8d, 0x1c,
+	0xa0, 0xc2, 0xe7, 0xf6, 0xf5, 0x19, 0xbc, 0xbf, 0xf4, 0x19, 0xf0, 0x4d, 0x06, 0xfe, 0xe0, 0x4f,
+	0x5d, 0x18, 0xba, 0x1f, 0x24, 0xf1, 0xa5, 0x30, 0x8f, 0xfa, 0xf1, 0x38, 0xa1, 0x53, 0x72, 0x4f,
+	0x0f, 0x71, 0xe1, 0x97, 0x49, 0xff, 0x4e, 0x39, 0x85, 0xda, 0xc7, 0xc9, 0xfd, 0x77, 0x90, 0xcd,
+	0x7e, 0x64, 0x5c, 0xc4, 0x56, 0xfd, 0x34, 0xe9, 0xb2, 0xd5, 0xbe, 0x4e, 0xee, 0xbf, 0x43, 0x3e,
+	0x83, 0xa1, 0xbb, 0x54, 0x4d, 0xb2, 0xea, 0xa7, 0x49, 0x97, 0xac, 0xb6, 0xba, 0x9a, 0x6c, 0xab,
+	0xfa, 0x51, 0x91, 0xf8, 0xad, 0x5f, 0x1a, 0x0d, 0xd5, 0x7b, 0x4b, 0xbe, 0x42, 0xee, 0xbf, 0x43,
+	0xbe, 0x80, 0x9d, 0xc6, 0x77, 0x34, 0xf2, 0xbe, 0xb6, 0x59, 0xf4, 0xfd, 0xce, 0xbf, 0xb7, 0xa8,
+	0x39, 0x67, 0x3d, 0x5f, 0xd3, 0x5f, 0x87, 0x1f, 0xfe, 0x6f, 0x00, 0x76, 0x40, 0x68, 0xc0, 0x33,
+	0x1e, 0x00, 0x00,
 }